@@ -0,0 +1,38 @@
+package main
+
+// FindPathWithAlternatives - shortest path from start to target, plus for each
+// index of the path where it could just as well have gone elsewhere, the other
+// cells it could have moved to next and still arrived as cheaply. Costs are
+// counted as FindPath counts them, moves plus the Weight of every cell entered.
+func FindPathWithAlternatives(grid Grid, start Point, target Point) (path []*Cell, branches map[int][]*Cell, err error) {
+	s := NewSolver(grid)
+
+	path, err = s.FindPath(start, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remaining := s.moveCostField(target, true, true)
+	branches = make(map[int][]*Cell)
+
+	var neighbourBuf [8]*Cell
+	var costBuf [8]int
+
+	for i := 0; i+1 < len(path); i++ {
+		cell := path[i]
+		neighbours, costs := s.neighbours(cell, neighbourBuf[:0], costBuf[:0])
+
+		for n, neighbour := range neighbours {
+			if neighbour == path[i+1] || !s.canClimb(cell, neighbour) {
+				continue
+			}
+
+			step := s.stepCost(cell, neighbour, costs[n]) + neighbour.Weight
+			if left := remaining[neighbour.Row][neighbour.Col]; left != -1 && step+left == remaining[cell.Row][cell.Col] {
+				branches[i] = append(branches[i], neighbour)
+			}
+		}
+	}
+
+	return path, branches, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindPathWithAlternativesSymmetricSplit(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		"..#..",
+		".....",
+	)
+	start, target := Point{0, 1}, Point{4, 1}
+
+	path, branches, err := FindPathWithAlternatives(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The path passes the wall on one side at index 1, the other side is as short
+	if path[1].Index() != (Point{1, 1}) {
+		t.Fatalf("path %v, want it to reach the split at index 1", PathPoints(path))
+	}
+
+	other := Point{2, 2 - path[2].Row}
+	if !containsPoint(branches[1], other) {
+		t.Fatalf("branches at the split %v, want %v", PathPoints(branches[1]), other)
+	}
+
+	// Weight on the top side leaves the bottom as the only cheapest way
+	grid[0][2].Weight = 5
+
+	path, branches, err = FindPathWithAlternatives(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cell := range path {
+		if cell.Row == 0 {
+			t.Fatalf("path %v goes over the weighted side", PathPoints(path))
+		}
+	}
+
+	for i, cells := range branches {
+		for _, cell := range cells {
+			if cell.Row == 0 {
+				t.Fatalf("index %d branches to %v over the weighted side", i, cell.Index())
+			}
+		}
+	}
+}
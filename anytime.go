@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+)
+
+// AnytimeWeightStep - how much FindPathAnytimeAll lowers the heuristic weight
+// between searches
+const AnytimeWeightStep = 0.5
+
+// FindPathAnytimeAll - every improving path of an anytime search, cheapest last.
+// Starts with the heuristic inflated by initialWeight, which finds a path fast
+// but not necessarily the shortest, then searches again with the weight lowered
+// by AnytimeWeightStep each time down to 1, where the path is the one FindPath
+// returns. Each search starts over rather than reusing the last one's work.
+// When ctx is done before then, the paths found so far are returned with
+// ctx.Err().
+func FindPathAnytimeAll(ctx context.Context, grid Grid, start Point, target Point, initialWeight float64) ([][]*Cell, error) {
+	s := NewSolver(grid)
+	base := s.Heuristic
+
+	var paths [][]*Cell
+	best := 0
+
+	for weight := max(initialWeight, 1); ; weight = max(weight-AnytimeWeightStep, 1) {
+		WithBlendedHeuristic(weight, base, 0, nil)(s)
+
+		st := s.NewStepper(start, target)
+		for !st.Step() {
+			if ctx.Err() != nil {
+				return paths, ctx.Err()
+			}
+		}
+
+		path, err := st.Result()
+		if err != nil {
+			// A lower weight won't find a path either
+			return nil, err
+		}
+
+		// At weight 1 a path as cheap as the best one is still added unless it's
+		// the same path, so the last one is always FindPath's
+		if len(paths) == 0 || s.Stats.Cost < best || (weight == 1 && !samePath(path, paths[len(paths)-1])) {
+			paths = append(paths, path)
+			best = s.Stats.Cost
+		}
+
+		if weight == 1 {
+			return paths, nil
+		}
+	}
+}
+
+// samePath - whether two paths go through the same cells in the same order
+func samePath(a []*Cell, b []*Cell) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFindPathAnytimeAllEndsOptimal(t *testing.T) {
+	grid := NewGrid(30, 20)
+	scatterWalls(30, 20, func(x int, y int) { grid[y][x].State = DISABLED })
+	start, target := Point{0, 0}, Point{29, 19}
+
+	paths, err := FindPathAnytimeAll(context.Background(), grid, start, target, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, path := range paths {
+		checkContiguous(t, path)
+
+		if i > 0 && moveCost(path) > moveCost(paths[i-1]) {
+			t.Fatalf("path %d costs %d, more than the %d before it", i, moveCost(path), moveCost(paths[i-1]))
+		}
+		if i > 0 && reflect.DeepEqual(PathPoints(path), PathPoints(paths[i-1])) {
+			t.Fatalf("path %d repeats the one before it", i)
+		}
+	}
+
+	optimal, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := paths[len(paths)-1]; !reflect.DeepEqual(PathPoints(last), PathPoints(optimal)) {
+		t.Fatalf("last path %v, FindPath gave %v", PathPoints(last), PathPoints(optimal))
+	}
+}
+
+func TestFindPathAnytimeAllNoRepeat(t *testing.T) {
+	// Nothing in the way, the first path is already the shortest
+	paths, err := FindPathAnytimeAll(context.Background(), NewGrid(5, 5), Point{0, 0}, Point{4, 4}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != 1 {
+		t.Fatalf("got %d paths, want the first one alone", len(paths))
+	}
+}
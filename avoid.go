@@ -0,0 +1,37 @@
+package main
+
+// FindPathAvoiding - shortest path from start to target that doesn't step on avoid,
+// as if avoid were a wall. The grid itself is left untouched.
+func FindPathAvoiding(grid Grid, start Point, target Point, avoid Point) ([]*Cell, error) {
+	if avoid == start || avoid == target {
+		return nil, &NoPathError{}
+	}
+
+	s := NewSolver(grid)
+	s.NeighbourFilter = func(from *Cell, to *Cell) bool {
+		return to.Index() != avoid
+	}
+
+	return s.FindPath(start, target)
+}
+
+// FindPathInRegion - shortest path from start to target that keeps to the cells
+// of region, as if every other cell were a wall. The grid itself is left
+// untouched.
+func FindPathInRegion(grid Grid, start Point, target Point, region []Point) ([]*Cell, error) {
+	allowed := make(map[Point]bool, len(region))
+	for _, p := range region {
+		allowed[p] = true
+	}
+
+	if !allowed[start] || !allowed[target] {
+		return nil, &NoPathError{}
+	}
+
+	s := NewSolver(grid)
+	s.NeighbourFilter = func(from *Cell, to *Cell) bool {
+		return allowed[to.Index()]
+	}
+
+	return s.FindPath(start, target)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindPathAvoidingChokepoint(t *testing.T) {
+	grid := parseGrid(
+		".......",
+		"###.##.",
+		".......",
+	)
+	start, target, choke := Point{3, 0}, Point{3, 2}, Point{3, 1}
+
+	best, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsPoint(best, choke) {
+		t.Fatalf("shortest path %v doesn't use the chokepoint", PathPoints(best))
+	}
+
+	path, err := FindPathAvoiding(grid, start, target, choke)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if containsPoint(path, choke) {
+		t.Fatalf("path %v goes through the avoided cell", PathPoints(path))
+	}
+	if len(path) <= len(best) {
+		t.Fatalf("path %v isn't longer than %v", PathPoints(path), PathPoints(best))
+	}
+
+	if !grid[choke.Y][choke.X].Walkable() {
+		t.Fatal("the avoided cell was left a wall")
+	}
+}
+
+func TestFindPathAvoidingEndpoint(t *testing.T) {
+	_, err := FindPathAvoiding(NewGrid(3, 3), Point{0, 0}, Point{2, 2}, Point{2, 2})
+
+	var noPath *NoPathError
+	if !errors.As(err, &noPath) {
+		t.Fatalf("got %v, want a *NoPathError", err)
+	}
+}
+
+func TestFindPathInRegion(t *testing.T) {
+	grid := NewGrid(5, 4)
+	start, target := Point{0, 0}, Point{4, 0}
+
+	// A U down the left side, along the bottom and back up the right
+	var region []Point
+	for y := 0; y < 4; y++ {
+		region = append(region, Point{0, y}, Point{4, y})
+	}
+	for x := 1; x < 4; x++ {
+		region = append(region, Point{x, 3})
+	}
+
+	best, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsPoint(best, Point{2, 3}) {
+		t.Fatalf("unconstrained path %v already keeps to the region", PathPoints(best))
+	}
+
+	path, err := FindPathInRegion(grid, start, target, region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	for _, cell := range path {
+		if cell.X != 0 && cell.X != 4 && cell.Y != 3 {
+			t.Fatalf("path %v leaves the region at %v", PathPoints(path), cell.Index())
+		}
+	}
+
+	_, err = FindPathInRegion(grid, start, Point{2, 1}, region)
+	var noPath *NoPathError
+	if !errors.As(err, &noPath) {
+		t.Fatalf("got %v for a target outside the region, want a *NoPathError", err)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"sync"
+)
+
+// Query - one start/target pair of a batch
+type Query struct {
+	Start  Point
+	Target Point
+}
+
+// Result - outcome of one Query
+type Result struct {
+	Path []*Cell
+	Err  error
+}
+
+// FindPathBatch - runs queries across workers goroutines, results are in query order.
+// All workers read the same grid, so it must not be modified until FindPathBatch
+// returns.
+func FindPathBatch(grid Grid, queries []Query, workers int) []Result {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Result, len(queries))
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			s := NewSolver(grid)
+
+			for i := range next {
+				path, err := s.FindPath(queries[i].Start, queries[i].Target)
+				results[i] = Result{path, err}
+			}
+		}()
+	}
+
+	for i := range queries {
+		next <- i
+	}
+
+	close(next)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Run with -race, workers share the grid
+func TestFindPathBatchMatchesSerial(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	grid := NewGrid(20, 20)
+	for i := 0; i < 80; i++ {
+		grid[rng.Intn(20)][rng.Intn(20)].State = DISABLED
+	}
+
+	queries := make([]Query, 200)
+	for i := range queries {
+		queries[i] = Query{Point{rng.Intn(20), rng.Intn(20)}, Point{rng.Intn(20), rng.Intn(20)}}
+	}
+
+	results := FindPathBatch(grid, queries, 8)
+
+	if len(results) != len(queries) {
+		t.Fatalf("%d results for %d queries", len(results), len(queries))
+	}
+
+	for i, q := range queries {
+		path, err := FindPath(grid, q.Start, q.Target)
+
+		if (err == nil) != (results[i].Err == nil) {
+			t.Fatalf("query %d: batch error %v, serial error %v", i, results[i].Err, err)
+		}
+
+		if !reflect.DeepEqual(PathPoints(results[i].Path), PathPoints(path)) {
+			t.Fatalf("query %d: batch path %v, serial path %v", i, PathPoints(results[i].Path), PathPoints(path))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math/rand"
+)
+
+// Betweenness - how many times each cell [row][column] lies on the shortest
+// path between samples random pairs of walkable cells. Pairs with no path
+// between them count for nothing.
+func Betweenness(grid Grid, samples int, rng *rand.Rand) [][]int {
+	counts := make([][]int, len(grid))
+	var walkable []Point
+
+	for y := range grid {
+		counts[y] = make([]int, len(grid[y]))
+
+		for x := range grid[y] {
+			if grid[y][x].State != DISABLED {
+				walkable = append(walkable, Point{x, y})
+			}
+		}
+	}
+
+	if len(walkable) < 2 {
+		return counts
+	}
+
+	s := NewSolver(grid)
+
+	for i := 0; i < samples; i++ {
+		start := walkable[rng.Intn(len(walkable))]
+		target := walkable[rng.Intn(len(walkable))]
+
+		path, err := s.FindPath(start, target)
+		if err != nil {
+			continue
+		}
+
+		for _, cell := range path {
+			counts[cell.Row][cell.Col]++
+		}
+	}
+
+	return counts
+}
+
+// WeightedPoint - grid point with how likely it is to be picked
+type WeightedPoint struct {
+	Point
+	Weight float64
+}
+
+// WeightedBetweenness - expected number of times each cell [row][column] lies
+// on the shortest path of a trip from a source to a sink, picking each in
+// proportion to its weight. Every source and sink pair is searched, pairs with
+// no path between them count for nothing.
+func WeightedBetweenness(grid Grid, sources []WeightedPoint, sinks []WeightedPoint) [][]float64 {
+	traffic := make([][]float64, len(grid))
+	for y := range grid {
+		traffic[y] = make([]float64, len(grid[y]))
+	}
+
+	sourceTotal, sinkTotal := 0.0, 0.0
+	for _, source := range sources {
+		sourceTotal += source.Weight
+	}
+	for _, sink := range sinks {
+		sinkTotal += sink.Weight
+	}
+
+	if sourceTotal <= 0 || sinkTotal <= 0 {
+		return traffic
+	}
+
+	w := NewSolver(grid).NewWorkspace()
+
+	for _, source := range sources {
+		for _, sink := range sinks {
+			p := source.Weight / sourceTotal * sink.Weight / sinkTotal
+			if p == 0 {
+				continue
+			}
+
+			path, err := w.Solve(source.Point, sink.Point)
+			if err != nil {
+				continue
+			}
+
+			for _, cell := range path {
+				traffic[cell.Row][cell.Col] += p
+			}
+		}
+	}
+
+	return traffic
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// twoRooms - two 4x5 rooms joined by a one cell door at (4, 2)
+func twoRooms() Grid {
+	return parseGrid(
+		"....#....",
+		"....#....",
+		".........",
+		"....#....",
+		"....#....",
+	)
+}
+
+func TestBetweennessChokepoint(t *testing.T) {
+	counts := Betweenness(twoRooms(), 300, rand.New(rand.NewSource(1)))
+
+	door := counts[2][4]
+	for y := range counts {
+		for x := range counts[y] {
+			if (x != 4 || y != 2) && counts[y][x] >= door {
+				t.Fatalf("cell %d, %d is on %d paths, the door only on %d", x, y, counts[y][x], door)
+			}
+		}
+	}
+}
+
+func TestWeightedBetweennessDominantPair(t *testing.T) {
+	grid := twoRooms()
+	sources := []WeightedPoint{{Point{0, 0}, 9}, {Point{0, 4}, 1}}
+	sinks := []WeightedPoint{{Point{8, 4}, 9}, {Point{8, 0}, 1}}
+
+	traffic := WeightedBetweenness(grid, sources, sinks)
+
+	path, err := NewSolver(grid).FindPath(Point{0, 0}, Point{8, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 0.81 of the trips take the route from (0, 0) to (8, 4), the other pairs
+	// together no more than 0.19
+	for y := range traffic {
+		for x := range traffic[y] {
+			if hot := traffic[y][x] > 0.5; hot != containsPoint(path, Point{x, y}) {
+				t.Fatalf("cell %d, %d has traffic %v, route %v", x, y, traffic[y][x], PathPoints(path))
+			}
+		}
+	}
+
+	if door := traffic[2][4]; door < 0.999 || door > 1.001 {
+		t.Fatalf("door has traffic %v, every trip goes through it", door)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"math"
+)
+
+// WithBlendedHeuristic - estimate a*h1 + b*h2. With non-negative weights adding
+// up to at most 1 and both heuristics admissible, the blend is admissible too.
+// Heavier weights push the search towards the target faster, at the price of
+// paths that may not be the shortest.
+func WithBlendedHeuristic(a float64, h1 Heuristic, b float64, h2 Heuristic) Option {
+	return func(s *Solver) {
+		s.Heuristic = func(from Point, to Point) int {
+			estimate := 0.0
+
+			if a != 0 {
+				estimate += a * float64(h1(from, to))
+			}
+			if b != 0 {
+				estimate += b * float64(h2(from, to))
+			}
+
+			return int(math.Round(estimate))
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBlendedHeuristic(t *testing.T) {
+	grid := parseGrid(
+		"........",
+		"..####..",
+		".....#..",
+		"..#..#..",
+		"........",
+	)
+	start, target := Point{0, 4}, Point{7, 0}
+
+	octile := NewSolver(grid, WithHeuristic(Octile))
+	want, err := octile.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the second heuristic the search is the same as with the first alone
+	single := NewSolver(grid, WithBlendedHeuristic(1, Octile, 0, Manhattan))
+	path, err := single.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(PathPoints(path), PathPoints(want)) || single.Stats.Expansions != octile.Stats.Expansions {
+		t.Fatalf("b = 0 gave %v after %d expansions, Octile %v after %d",
+			PathPoints(path), single.Stats.Expansions, PathPoints(want), octile.Stats.Expansions)
+	}
+
+	// Admissible blend, still the cheapest path
+	landmarks := PrecomputeLandmarks(grid, 3)
+	blend := NewSolver(grid, WithBlendedHeuristic(0.5, Octile, 0.5, landmarks.Heuristic))
+	path, err = blend.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	if blend.Stats.Cost != octile.Stats.Cost {
+		t.Fatalf("blend costs %d, want %d", blend.Stats.Cost, octile.Stats.Cost)
+	}
+}
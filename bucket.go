@@ -0,0 +1,136 @@
+package main
+
+import (
+	"sort"
+)
+
+// BucketQueue - OpenSet with one bucket per priority, for the small whole-number
+// costs of a grid. Pops without the sifting of a heap, though on typical grids
+// it runs about as fast as HeapOpenSet (see BenchmarkBucketQueue). Priorities
+// may be negative, the buckets span the lowest to the highest one pushed. Ties
+// break on the tie key, then in push order, like HeapOpenSet.
+type BucketQueue struct {
+	// buckets[i] - entries with priority base+i, in push order
+	buckets [][]*openEntry
+	base    int
+	entries map[*Cell]*openEntry
+
+	// lowest - index of the first bucket that may hold entries
+	lowest int
+	count  int
+	pushed int
+	tieKey func(cell *Cell) int
+
+	// free - popped entries, handed out again by Push
+	free []*openEntry
+}
+
+func NewBucketQueue() OpenSet {
+	return &BucketQueue{entries: make(map[*Cell]*openEntry)}
+}
+
+func (q *BucketQueue) Push(cell *Cell, priority int) {
+	var entry *openEntry
+	if n := len(q.free); n > 0 {
+		entry = q.free[n-1]
+		q.free = q.free[:n-1]
+	} else {
+		entry = &openEntry{}
+	}
+
+	*entry = openEntry{cell: cell, tie: tieOf(q.tieKey, cell), seq: q.pushed}
+	q.pushed++
+	q.entries[cell] = entry
+
+	q.insert(entry, priority)
+	q.count++
+}
+
+func (q *BucketQueue) Pop() *Cell {
+	if q.count == 0 {
+		return nil
+	}
+
+	for len(q.buckets[q.lowest]) == 0 {
+		q.lowest++
+	}
+
+	bucket := q.buckets[q.lowest]
+
+	// First entry with the lowest tie key, the bucket is in push order
+	first := 0
+	if q.tieKey != nil {
+		for i, entry := range bucket {
+			if entry.tie < bucket[first].tie {
+				first = i
+			}
+		}
+	}
+
+	entry := bucket[first]
+	if first == 0 {
+		q.buckets[q.lowest] = bucket[1:]
+	} else {
+		q.buckets[q.lowest] = append(bucket[:first], bucket[first+1:]...)
+	}
+
+	delete(q.entries, entry.cell)
+	q.count--
+	q.free = append(q.free, entry)
+
+	return entry.cell
+}
+
+func (q *BucketQueue) Update(cell *Cell, priority int) {
+	entry := q.entries[cell]
+
+	// Take it out of its old bucket, then file it under the new priority
+	old := entry.priority - q.base
+	bucket := q.buckets[old]
+	i := sort.Search(len(bucket), func(i int) bool { return bucket[i].seq >= entry.seq })
+	q.buckets[old] = append(bucket[:i], bucket[i+1:]...)
+
+	entry.tie = tieOf(q.tieKey, cell)
+	q.insert(entry, priority)
+}
+
+func (q *BucketQueue) Len() int {
+	return q.count
+}
+
+func (q *BucketQueue) SetTieBreak(key func(cell *Cell) int) {
+	q.tieKey = key
+}
+
+// insert - adds entry to the bucket for priority, keeping the bucket in push order
+func (q *BucketQueue) insert(entry *openEntry, priority int) {
+	if len(q.buckets) == 0 {
+		q.base = priority
+	}
+
+	if priority < q.base {
+		// Room for the lower priorities in front of the others
+		shift := q.base - priority
+		q.buckets = append(make([][]*openEntry, shift, shift+len(q.buckets)), q.buckets...)
+		q.base = priority
+		q.lowest += shift
+	}
+
+	index := priority - q.base
+	for len(q.buckets) <= index {
+		q.buckets = append(q.buckets, nil)
+	}
+
+	entry.priority = priority
+	bucket := q.buckets[index]
+
+	i := sort.Search(len(bucket), func(i int) bool { return bucket[i].seq > entry.seq })
+	bucket = append(bucket, nil)
+	copy(bucket[i+1:], bucket[i:])
+	bucket[i] = entry
+	q.buckets[index] = bucket
+
+	if q.count == 0 || index < q.lowest {
+		q.lowest = index
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBucketQueueFindsSamePaths(t *testing.T) {
+	sets := map[string]func() OpenSet{"bucket": NewBucketQueue}
+
+	checkSameSearch(t, sets)
+
+	// Tailwinds take priorities below 0
+	tailwind := func(s *Solver) { s.DirectionCost = map[Direction]int{EAST: -6, NORTHEAST: -6} }
+	checkSameSearch(t, sets, tailwind)
+
+	// Ties broken on diagonal moves rather than push order
+	checkSameSearch(t, sets, func(s *Solver) { s.PreferDiagonals = true }, WithCosts(10, 20))
+}
+
+func TestBucketQueueOrder(t *testing.T) {
+	grid := NewGrid(5, 1)
+	q := NewBucketQueue()
+
+	if q.Pop() != nil {
+		t.Fatal("empty queue popped a cell")
+	}
+
+	q.Push(grid[0][0], 3)
+	q.Push(grid[0][1], -5)
+	q.Push(grid[0][2], 0)
+	q.Push(grid[0][3], -5)
+	q.Push(grid[0][4], 7)
+	q.Update(grid[0][4], -9)
+
+	for _, want := range []int{4, 1, 3, 2, 0} {
+		if cell := q.Pop(); cell != grid[0][want] {
+			t.Fatalf("popped %v, want %v", cell.Index(), grid[0][want].Index())
+		}
+	}
+
+	if q.Len() != 0 || q.Pop() != nil {
+		t.Fatal("queue not empty after popping everything")
+	}
+}
+
+// benchmarkOpenSet - corner to corner searches of a 200x200 grid with scattered
+// walls
+func benchmarkOpenSet(b *testing.B, newSet func() OpenSet) {
+	rng := rand.New(rand.NewSource(4))
+	grid := NewGrid(200, 200)
+	for i := 0; i < 8000; i++ {
+		grid[rng.Intn(200)][rng.Intn(200)].State = DISABLED
+	}
+	grid[0][0].State, grid[199][199].State = UNSEEN, UNSEEN
+
+	s := NewSolver(grid, WithOpenSet(newSet))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.FindPath(Point{0, 0}, Point{199, 199}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHeapOpenSet(b *testing.B) {
+	benchmarkOpenSet(b, NewHeapOpenSet)
+}
+
+func BenchmarkBucketQueue(b *testing.B) {
+	benchmarkOpenSet(b, NewBucketQueue)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"container/list"
+	"maps"
+	"reflect"
+)
+
+// WithCache - remember the last size paths found, by start and target. Meant for
+// static maps, the cache is dropped when walls change through SetWalkable or
+// AddEdgeWall or any setting of the solver changes. A function setting only
+// counts as changed when a different function is set, not a new closure of the
+// same one.
+func (s *Solver) WithCache(size int) *Solver {
+	s.cache = &pathCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[Query]*list.Element),
+	}
+
+	return s
+}
+
+// SetWalkable - turns the cell at x, y into a wall or back into open ground
+func (s *Solver) SetWalkable(x int, y int, walkable bool) {
+	if !s.Grid.InBounds(x, y) {
+		return
+	}
+
+	cell := s.Grid[y][x]
+
+	if walkable == (cell.State != DISABLED) {
+		return
+	}
+
+	if walkable {
+		cell.State = UNSEEN
+	} else {
+		cell.State = DISABLED
+	}
+
+	if s.cache != nil {
+		s.cache.clear()
+	}
+
+	// Regions and clearance are rebuilt by the next search that needs them
+	s.components = nil
+	s.clearance = nil
+}
+
+// pathCache - least recently used paths, most recent at the front of order
+type pathCache struct {
+	size    int
+	order   *list.List
+	entries map[Query]*list.Element
+
+	// settings - solver settings the paths were found under, with copies of
+	// its maps so changes made in place are seen too
+	settings      cacheSettings
+	congestion    map[Point]int
+	directionCost map[Direction]int
+	surcharge     map[Point]int
+}
+
+type cacheEntry struct {
+	key  Query
+	path []*Cell
+	cost int
+
+	// optimal - Stats.Optimal of the search that found path
+	optimal bool
+}
+
+func (c *pathCache) get(key Query) (cacheEntry, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	entry := *elem.Value.(*cacheEntry)
+
+	// Copy so callers can't rewrite the cached path
+	entry.path = append([]*Cell(nil), entry.path...)
+
+	return entry, true
+}
+
+func (c *pathCache) add(key Query, path []*Cell, stats Stats) {
+	if c.size <= 0 {
+		return
+	}
+
+	entry := &cacheEntry{key, append([]*Cell(nil), path...), stats.Cost, stats.Optimal}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// sync - drops the paths when the settings differ from the ones they were found
+// under
+func (c *pathCache) sync(s *Solver) {
+	settings := s.cacheSettings()
+
+	if settings != c.settings || !maps.Equal(s.Congestion, c.congestion) ||
+		!maps.Equal(s.DirectionCost, c.directionCost) || !maps.Equal(s.surcharge, c.surcharge) {
+		c.clear()
+		c.settings = settings
+		c.congestion = maps.Clone(s.Congestion)
+		c.directionCost = maps.Clone(s.DirectionCost)
+		c.surcharge = maps.Clone(s.surcharge)
+	}
+}
+
+func (c *pathCache) clear() {
+	c.order.Init()
+	c.entries = make(map[Query]*list.Element)
+}
+
+// cacheSettings - every setting of the solver that can change a path, other
+// than the maps, which pathCache keeps copies of. Functions are kept by address.
+type cacheSettings struct {
+	movement         MovementMode
+	straightCost     int
+	diagonalCost     int
+	congestionCost   int
+	snapToWalkable   bool
+	cellWidth        float64
+	cellHeight       float64
+	backtrackPenalty int
+	fatigue          float64
+	lazyHeuristic    bool
+	goodEnoughH      int
+	heuristicWeight  float64
+	maxSteps         int
+	adaptiveWeight   float64
+	maxReopens       int
+	greedy           bool
+	firstPath        bool
+	preferDiagonals  bool
+	sortNeighbours   bool
+	maxDiagonalRun   int
+	turnWeight       int
+	preferredTag     int
+	tagDiscount      int
+	exposedCost      int
+	requireLOS       bool
+	maxClimb         int
+	cornerCost       int
+	useComponents    bool
+	agentRadius      int
+	landmarks        *Landmarks
+
+	heuristic       uintptr
+	newOpenSet      uintptr
+	cellCost        uintptr
+	neighbours      uintptr
+	neighbourFilter uintptr
+	priorityBias    uintptr
+	turnCostFunc    uintptr
+	exposed         uintptr
+	goal            uintptr
+}
+
+func (s *Solver) cacheSettings() cacheSettings {
+	return cacheSettings{
+		movement:         s.Movement,
+		straightCost:     s.StraightCost,
+		diagonalCost:     s.DiagonalCost,
+		congestionCost:   s.CongestionCost,
+		snapToWalkable:   s.SnapToWalkable,
+		cellWidth:        s.CellWidth,
+		cellHeight:       s.CellHeight,
+		backtrackPenalty: s.BacktrackPenalty,
+		fatigue:          s.Fatigue,
+		lazyHeuristic:    s.LazyHeuristic,
+		goodEnoughH:      s.GoodEnoughH,
+		heuristicWeight:  s.HeuristicWeight,
+		maxSteps:         s.MaxSteps,
+		adaptiveWeight:   s.AdaptiveWeight,
+		maxReopens:       s.MaxReopens,
+		greedy:           s.Greedy,
+		firstPath:        s.FirstPath,
+		preferDiagonals:  s.PreferDiagonals,
+		sortNeighbours:   s.SortNeighbours,
+		maxDiagonalRun:   s.MaxDiagonalRun,
+		turnWeight:       s.TurnWeight,
+		preferredTag:     s.PreferredTag,
+		tagDiscount:      s.TagDiscount,
+		exposedCost:      s.ExposedCost,
+		requireLOS:       s.RequireLOSFromStart,
+		maxClimb:         s.MaxClimb,
+		cornerCost:       s.CornerCost,
+		useComponents:    s.useComponents,
+		agentRadius:      s.agentRadius,
+		landmarks:        s.landmarks,
+
+		heuristic:       funcAddr(s.Heuristic),
+		newOpenSet:      funcAddr(s.NewOpenSet),
+		cellCost:        funcAddr(s.CellCost),
+		neighbours:      funcAddr(s.Neighbours),
+		neighbourFilter: funcAddr(s.NeighbourFilter),
+		priorityBias:    funcAddr(s.PriorityBias),
+		turnCostFunc:    funcAddr(s.TurnCostFunc),
+		exposed:         funcAddr(s.Exposed),
+		goal:            funcAddr(s.goal),
+	}
+}
+
+// funcAddr - code address of a function, 0 for nil
+func funcAddr(f interface{}) uintptr {
+	return reflect.ValueOf(f).Pointer()
+}
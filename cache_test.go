@@ -0,0 +1,109 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCacheHitSkipsSearch(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		".###.",
+		".....",
+	)
+	s := NewSolver(grid).WithCache(4)
+
+	first, err := s.FindPath(Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cost := s.Stats.Cost
+
+	second, err := s.FindPath(Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Stats.Expansions != 0 {
+		t.Fatalf("cache hit expanded %d cells", s.Stats.Expansions)
+	}
+	if s.Stats.Cost != cost {
+		t.Fatalf("cache hit costs %d, want %d", s.Stats.Cost, cost)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("cache hit %v, want %v", PathPoints(second), PathPoints(first))
+	}
+}
+
+func TestCacheDroppedOnWallChange(t *testing.T) {
+	s := NewSolver(NewGrid(5, 3)).WithCache(4)
+
+	if _, err := s.FindPath(Point{0, 1}, Point{4, 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetWalkable(2, 1, false)
+
+	path, err := s.FindPath(Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Stats.Expansions == 0 || containsPoint(path, Point{2, 1}) {
+		t.Fatalf("got cached path %v through the new wall", PathPoints(path))
+	}
+}
+
+func TestCacheDroppedOnSettingChange(t *testing.T) {
+	s := NewSolver(NewGrid(5, 5)).WithCache(4)
+
+	if _, err := s.FindPath(Point{0, 0}, Point{4, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.DiagonalCost = 30
+
+	if _, err := s.FindPath(Point{0, 0}, Point{4, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Stats.Expansions == 0 || s.Stats.Cost != 80 {
+		t.Fatalf("got cost %d after %d expansions, want a new search costing 80", s.Stats.Cost, s.Stats.Expansions)
+	}
+}
+
+func TestCacheDroppedOnMapChange(t *testing.T) {
+	s := NewSolver(NewGrid(5, 1)).WithCache(4)
+	s.Congestion, s.CongestionCost = map[Point]int{}, 10
+
+	if _, err := s.FindPath(Point{0, 0}, Point{4, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same map, changed in place
+	s.Congestion[Point{2, 0}] = 1
+
+	if _, err := s.FindPath(Point{0, 0}, Point{4, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Stats.Expansions == 0 || s.Stats.Cost != 50 {
+		t.Fatalf("got cost %d after %d expansions, want a new search costing 50", s.Stats.Cost, s.Stats.Expansions)
+	}
+
+	// Nothing changed, so the path comes from the cache
+	if _, err := s.FindPath(Point{0, 0}, Point{4, 0}); err != nil || s.Stats.Expansions != 0 {
+		t.Fatalf("got %v after %d expansions, want a cache hit", err, s.Stats.Expansions)
+	}
+}
+
+func TestSetWalkableOutOfBounds(t *testing.T) {
+	s := NewSolver(NewGrid(3, 3))
+
+	s.SetWalkable(-1, 0, false)
+	s.SetWalkable(3, 3, false)
+
+	if _, err := s.FindPath(Point{0, 0}, Point{2, 2}); err != nil {
+		t.Fatal(err)
+	}
+}
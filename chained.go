@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+)
+
+// ErrChainTooLong - returned when chained goals keep handing over past the
+// number of links allowed
+var ErrChainTooLong = errors.New("chain of goals too long")
+
+// FindPathChainedGoals - path from start through each goal in turn, where
+// reaching a goal swaps in the next one. Every leg is a separate shortest path,
+// the legs are joined into one path without repeating the cells they share.
+func FindPathChainedGoals(grid Grid, start Point, goals []Point) ([]*Cell, error) {
+	if len(goals) == 0 {
+		return nil, ErrNoWaypoints
+	}
+
+	i := 0
+	next := func(Point) (Point, bool) {
+		i++
+		if i == len(goals) {
+			return Point{}, false
+		}
+
+		return goals[i], true
+	}
+
+	return FindPathChainedGoalsFunc(grid, start, goals[0], next, len(goals))
+}
+
+// FindPathChainedGoalsFunc - FindPathChainedGoals for goals that are only known
+// once the one before is reached, as when reaching a cell moves the goal
+// elsewhere. next is told which goal was reached and hands back the one to head
+// for from there, or false once the path is complete; a nil next stops at the
+// first goal. Gives up with ErrChainTooLong after maxLinks goals, so a next that
+// never ends the chain can't keep it going forever.
+func FindPathChainedGoalsFunc(grid Grid, start Point, goal Point, next func(reached Point) (Point, bool), maxLinks int) ([]*Cell, error) {
+	s := NewSolver(grid)
+
+	var path []*Cell
+	from := start
+
+	for links := 1; ; links++ {
+		if links > maxLinks {
+			return nil, ErrChainTooLong
+		}
+
+		leg, err := s.FindPath(from, goal)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(path) > 0 {
+			leg = leg[1:]
+		}
+
+		path = append(path, leg...)
+		from = goal
+
+		more := false
+		if next != nil {
+			goal, more = next(from)
+		}
+
+		if !more {
+			return path, nil
+		}
+	}
+}
+
+// ConnectWaypoints - full cell by cell path through sparse waypoints, e.g. from
+// clicks or a coarse planner, with the shortest path between each pair
+func ConnectWaypoints(grid Grid, waypoints []Point) ([]*Cell, error) {
+	if len(waypoints) == 0 {
+		return nil, ErrNoWaypoints
+	}
+
+	// The first leg goes nowhere, it only checks the first waypoint
+	return FindPathChainedGoals(grid, waypoints[0], waypoints)
+}
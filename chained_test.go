@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindPathChainedGoals(t *testing.T) {
+	grid := parseGrid(
+		"......",
+		".####.",
+		"......",
+	)
+
+	// Across the top to the pad, which sends the goal back along the bottom
+	goals := []Point{{5, 0}, {0, 2}}
+
+	path, err := FindPathChainedGoals(grid, Point{0, 0}, goals)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	if !containsPoint(path, goals[0]) {
+		t.Fatalf("path %v misses the first goal %v", PathPoints(path), goals[0])
+	}
+	if first, last := path[0].Index(), path[len(path)-1].Index(); first != (Point{0, 0}) || last != goals[1] {
+		t.Fatalf("path runs from %v to %v, want (0, 0) to %v", first, last, goals[1])
+	}
+	if len(path) != 12 {
+		t.Fatalf("path %v has %d cells, want 12 with the shared pad counted once", PathPoints(path), len(path))
+	}
+
+	if _, err := FindPathChainedGoals(grid, Point{0, 0}, nil); !errors.Is(err, ErrNoWaypoints) {
+		t.Fatalf("got %v for no goals, want ErrNoWaypoints", err)
+	}
+}
+
+func TestFindPathChainedGoalsFunc(t *testing.T) {
+	grid := parseGrid(
+		"......",
+		".####.",
+		"......",
+	)
+
+	// Reaching the pad at the far end of the top row sends the goal back to the
+	// bottom left, which ends the chain
+	pad, exit := Point{5, 0}, Point{0, 2}
+	var reached []Point
+
+	next := func(p Point) (Point, bool) {
+		reached = append(reached, p)
+
+		return exit, p == pad
+	}
+
+	path, err := FindPathChainedGoalsFunc(grid, Point{0, 0}, pad, next, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	if !containsPoint(path, pad) {
+		t.Fatalf("path %v misses the first goal %v", PathPoints(path), pad)
+	}
+	if first, last := path[0].Index(), path[len(path)-1].Index(); first != (Point{0, 0}) || last != exit {
+		t.Fatalf("path runs from %v to %v, want (0, 0) to %v", first, last, exit)
+	}
+	if len(reached) != 2 || reached[0] != pad || reached[1] != exit {
+		t.Fatalf("next was told of %v, want %v then %v", reached, pad, exit)
+	}
+}
+
+func TestFindPathChainedGoalsFuncNoNext(t *testing.T) {
+	path, err := FindPathChainedGoalsFunc(NewGrid(3, 1), Point{0, 0}, Point{2, 0}, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(path) != 3 {
+		t.Fatalf("path %v, want the three cells to the goal", PathPoints(path))
+	}
+}
+
+func TestFindPathChainedGoalsFuncMaxLinks(t *testing.T) {
+	// Bounces between the two ends for as long as it's let
+	calls := 0
+	next := func(p Point) (Point, bool) {
+		calls++
+
+		return Point{2 - p.X, 0}, true
+	}
+
+	if _, err := FindPathChainedGoalsFunc(NewGrid(3, 1), Point{0, 0}, Point{2, 0}, next, 5); !errors.Is(err, ErrChainTooLong) {
+		t.Fatalf("got %v for a chain that never ends, want ErrChainTooLong", err)
+	}
+	if calls != 5 {
+		t.Fatalf("next called %d times, want 5", calls)
+	}
+}
+
+func TestConnectWaypointsAroundObstacle(t *testing.T) {
+	grid := parseGrid(
+		".......",
+		"...#...",
+		"...#...",
+		"...#...",
+		".......",
+	)
+	waypoints := []Point{{0, 2}, {3, 0}, {6, 2}}
+
+	path, err := ConnectWaypoints(grid, waypoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	for _, p := range waypoints {
+		if !containsPoint(path, p) {
+			t.Fatalf("path %v misses waypoint %v", PathPoints(path), p)
+		}
+	}
+	if first, last := path[0].Index(), path[len(path)-1].Index(); first != waypoints[0] || last != waypoints[2] {
+		t.Fatalf("path runs from %v to %v, want %v to %v", first, last, waypoints[0], waypoints[2])
+	}
+
+	if _, err := ConnectWaypoints(grid, nil); !errors.Is(err, ErrNoWaypoints) {
+		t.Fatalf("got %v for no waypoints, want ErrNoWaypoints", err)
+	}
+}
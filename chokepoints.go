@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// RankedCell - cell and how much it matters, higher first
+type RankedCell struct {
+	Cell   *Cell
+	Impact int
+}
+
+// Chokepoints - cells whose loss as a wall would add the most to the cost of
+// the shortest paths between samples random pairs of walkable cells, most first.
+// A pair cut off completely counts as DiagonalCost per cell of the grid, more
+// than any path could cost. Only cells on the sampled paths can matter, each of
+// them is tried against every pair, so keep samples small on big grids. Cells
+// that make no difference are left out.
+func Chokepoints(grid Grid, samples int, rng *rand.Rand) []RankedCell {
+	var walkable []Point
+	cells := 0
+
+	for y := range grid {
+		cells += len(grid[y])
+
+		for x := range grid[y] {
+			if grid[y][x].Walkable() {
+				walkable = append(walkable, Point{x, y})
+			}
+		}
+	}
+
+	if len(walkable) < 2 {
+		return nil
+	}
+
+	s := NewSolver(grid)
+	w := s.NewWorkspace()
+	cutOff := cells * s.DiagonalCost
+
+	type pair struct {
+		start, target Point
+		cost          int
+	}
+
+	var pairs []pair
+	candidates := make(map[*Cell]bool)
+
+	for i := 0; i < samples; i++ {
+		start := walkable[rng.Intn(len(walkable))]
+		target := walkable[rng.Intn(len(walkable))]
+		if start == target {
+			// Nothing between them to block
+			continue
+		}
+
+		path, err := w.Solve(start, target)
+		if err != nil {
+			continue
+		}
+
+		pairs = append(pairs, pair{start, target, s.Stats.Cost})
+
+		for _, cell := range path[1 : len(path)-1] {
+			candidates[cell] = true
+		}
+	}
+
+	var blocked *Cell
+	s.NeighbourFilter = func(from *Cell, to *Cell) bool {
+		return to != blocked
+	}
+
+	var ranked []RankedCell
+
+	for cell := range candidates {
+		blocked = cell
+		impact := 0
+
+		for _, p := range pairs {
+			if p.start == cell.Index() || p.target == cell.Index() {
+				continue
+			}
+
+			if _, err := w.Solve(p.start, p.target); err != nil {
+				impact += cutOff
+			} else {
+				impact += s.Stats.Cost - p.cost
+			}
+		}
+
+		if impact > 0 {
+			ranked = append(ranked, RankedCell{cell, impact})
+		}
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Impact != b.Impact {
+			return a.Impact > b.Impact
+		}
+
+		return a.Cell.Row < b.Cell.Row || (a.Cell.Row == b.Cell.Row && a.Cell.Col < b.Cell.Col)
+	})
+
+	return ranked
+}
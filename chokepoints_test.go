@@ -0,0 +1,23 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestChokepointsTwoRooms(t *testing.T) {
+	ranked := Chokepoints(twoRooms(), 40, rand.New(rand.NewSource(2)))
+
+	if len(ranked) == 0 || ranked[0].Cell.Index() != (Point{4, 2}) {
+		t.Fatalf("ranked %v, want the door at 4, 2 first", ranked)
+	}
+}
+
+func TestChokepointsSameStartAndTarget(t *testing.T) {
+	// Two walkable cells, so about half the samples pick the same one twice
+	grid := parseGrid("#..#")
+
+	if ranked := Chokepoints(grid, 20, rand.New(rand.NewSource(1))); len(ranked) != 0 {
+		t.Fatalf("ranked %v, no cell lies between two others", ranked)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+// SplitByChunk - cuts path into runs of consecutive cells in the same
+// chunkW x chunkH chunk of the world. Every run after the first starts with the
+// last cell of the run before it, so runs join up into the full path. Chunk
+// sizes below 1 leave the whole path as one run.
+func SplitByChunk(path []*Cell, chunkW int, chunkH int) [][]*Cell {
+	if len(path) == 0 {
+		return nil
+	}
+
+	if chunkW < 1 || chunkH < 1 {
+		return [][]*Cell{path}
+	}
+
+	var parts [][]*Cell
+
+	for i, cell := range path {
+		if i == 0 || chunkOf(cell, chunkW, chunkH) != chunkOf(path[i-1], chunkW, chunkH) {
+			part := []*Cell{cell}
+			if i > 0 {
+				part = []*Cell{path[i-1], cell}
+			}
+
+			parts = append(parts, part)
+			continue
+		}
+
+		parts[len(parts)-1] = append(parts[len(parts)-1], cell)
+	}
+
+	return parts
+}
+
+// chunkOf - chunk holding the cell's world position, rounding down for negatives
+func chunkOf(cell *Cell, chunkW int, chunkH int) Point {
+	return Point{floorDiv(cell.X, chunkW), floorDiv(cell.Y, chunkH)}
+}
+
+func floorDiv(a int, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+
+	return q
+}
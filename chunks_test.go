@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitByChunkTwoChunks(t *testing.T) {
+	grid := NewGrid(8, 2)
+	path, err := FindPath(grid, Point{1, 0}, Point{6, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := SplitByChunk(path, 4, 4)
+
+	if len(parts) != 2 {
+		t.Fatalf("got %d runs, want 2", len(parts))
+	}
+
+	want := [][]Point{
+		{{1, 0}, {2, 0}, {3, 0}},
+		{{3, 0}, {4, 0}, {5, 0}, {6, 0}},
+	}
+	for i := range parts {
+		if !reflect.DeepEqual(PathPoints(parts[i]), want[i]) {
+			t.Fatalf("run %d is %v, want %v", i, PathPoints(parts[i]), want[i])
+		}
+	}
+
+	// Every run starts where the one before it ended
+	if parts[1][0] != parts[0][len(parts[0])-1] {
+		t.Fatal("runs don't join up")
+	}
+}
+
+func TestSplitByChunkBadSize(t *testing.T) {
+	path, err := FindPath(NewGrid(4, 1), Point{0, 0}, Point{3, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range [][2]int{{0, 4}, {4, 0}, {-1, -1}} {
+		parts := SplitByChunk(path, size[0], size[1])
+
+		if len(parts) != 1 || len(parts[0]) != len(path) {
+			t.Fatalf("chunk size %v: got %d runs, want the whole path", size, len(parts))
+		}
+	}
+}
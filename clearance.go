@@ -0,0 +1,128 @@
+package main
+
+import (
+	"sort"
+)
+
+// clearanceField - steps to the nearest wall for every cell [row][column],
+// counting diagonal steps as one and the outside of the grid as wall. Walls are
+// 0, cells touching a wall, an edge wall or the edge are 1.
+func (g Grid) clearanceField() [][]int {
+	clearance := make([][]int, len(g))
+	var queue []Point
+
+	for y := range g {
+		clearance[y] = make([]int, len(g[y]))
+
+		for x := range g[y] {
+			switch {
+			case g[y][x].State == DISABLED:
+				clearance[y][x] = 0
+				queue = append(queue, Point{x, y})
+			case x == 0 || y == 0 || y == len(g)-1 || x == len(g[y])-1 || g[y][x].EdgeWalls != 0:
+				clearance[y][x] = 1
+				queue = append(queue, Point{x, y})
+			default:
+				clearance[y][x] = -1
+			}
+		}
+	}
+
+	// Breadth first out of the walls and edges, walls first so they win ties
+	sort.SliceStable(queue, func(i, j int) bool {
+		return clearance[queue[i].Y][queue[i].X] < clearance[queue[j].Y][queue[j].X]
+	})
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				x, y := p.X+dx, p.Y+dy
+
+				if g.InBounds(x, y) && clearance[y][x] == -1 {
+					clearance[y][x] = clearance[p.Y][p.X] + 1
+					queue = append(queue, Point{x, y})
+				}
+			}
+		}
+	}
+
+	return clearance
+}
+
+// WithAgentRadius - keep the search out of cells within r steps of a wall or
+// the edge of the grid, counting diagonal steps as one, for an agent that's
+// bigger than a cell. Only the start may break the rule. Uses the distance to
+// the nearest wall of every cell, worked out when first needed and again after
+// walls change through SetWalkable or AddEdgeWall.
+func WithAgentRadius(r int) Option {
+	return func(s *Solver) {
+		s.agentRadius = r
+		s.clearance = nil
+	}
+}
+
+// fits - whether an agent of the solver's radius fits in cell
+func (s *Solver) fits(cell *Cell) bool {
+	if s.agentRadius <= 0 {
+		return true
+	}
+
+	if s.clearance == nil {
+		s.clearance = s.Grid.clearanceField()
+	}
+
+	return s.clearance[cell.Row][cell.Col] > s.agentRadius
+}
+
+// SafestPath - of the paths costing at most delta more than the shortest one,
+// the one that keeps furthest from walls at its closest point, cheapest first
+// on ties. The start and target themselves may be as close to walls as they like.
+func SafestPath(grid Grid, start Point, target Point, delta int) ([]*Cell, error) {
+	s := NewSolver(grid)
+
+	shortest, err := s.FindPath(start, target)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := s.Stats.Cost + delta
+	clearance := grid.clearanceField()
+
+	// Only cells on some path within budget matter
+	fromStart := grid.DistanceField(start)
+	toTarget := grid.DistanceField(target)
+
+	var levels []int
+	seen := make(map[int]bool)
+
+	for y := range grid {
+		for x := range grid[y] {
+			if fromStart[y][x] == -1 || toTarget[y][x] == -1 || fromStart[y][x]+toTarget[y][x] > budget {
+				continue
+			}
+
+			if c := clearance[y][x]; !seen[c] {
+				seen[c] = true
+				levels = append(levels, c)
+			}
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+
+	for _, level := range levels {
+		s.NeighbourFilter = func(from *Cell, to *Cell) bool {
+			return to.Index() == target || clearance[to.Row][to.Col] >= level
+		}
+
+		path, err := s.FindPath(start, target)
+		if err == nil && s.Stats.Cost <= budget {
+			return path, nil
+		}
+	}
+
+	return shortest, nil
+}
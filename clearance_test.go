@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+)
+
+// minClearance - steps to the nearest wall of the closest approach of path,
+// start and target left out
+func minClearance(grid Grid, path []*Cell) int {
+	clearance := grid.clearanceField()
+
+	closest := -1
+	for _, cell := range path[1 : len(path)-1] {
+		if c := clearance[cell.Row][cell.Col]; closest == -1 || c < closest {
+			closest = c
+		}
+	}
+
+	return closest
+}
+
+func TestSafestPathLeavesTheWall(t *testing.T) {
+	grid := NewGrid(9, 7)
+	start, target := Point{1, 0}, Point{7, 0}
+
+	s := NewSolver(grid)
+	shortest, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minClearance(grid, shortest) != 1 {
+		t.Fatalf("shortest path %v doesn't hug the edge", PathPoints(shortest))
+	}
+
+	path, err := SafestPath(grid, start, target, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	if c := minClearance(grid, path); c != 2 {
+		t.Fatalf("path %v comes within %d of a wall, want 2", PathPoints(path), c)
+	}
+	if cost := moveCost(path); cost > s.Stats.Cost+20 {
+		t.Fatalf("path %v costs %d, over the budget of %d", PathPoints(path), cost, s.Stats.Cost+20)
+	}
+
+	// Without budget to spare the shortest path is all there is
+	tight, err := SafestPath(grid, start, target, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moveCost(tight) != s.Stats.Cost {
+		t.Fatalf("path %v costs %d with no budget, want %d", PathPoints(tight), moveCost(tight), s.Stats.Cost)
+	}
+}
+
+func TestWithAgentRadiusNarrowGap(t *testing.T) {
+	grid := parseGrid(
+		".........",
+		".........",
+		".........",
+		".........",
+		"##.##....",
+		".........",
+		".........",
+		".........",
+		".........",
+	)
+	start, target, gap := Point{2, 1}, Point{2, 7}, Point{2, 4}
+
+	small, err := NewSolver(grid, WithAgentRadius(0)).FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsPoint(small, gap) {
+		t.Fatalf("radius 0 path %v doesn't use the narrow gap", PathPoints(small))
+	}
+
+	big, err := NewSolver(grid, WithAgentRadius(1)).FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsPoint(big, gap) {
+		t.Fatalf("radius 1 path %v squeezes through the narrow gap", PathPoints(big))
+	}
+	if !containsPoint(big, Point{6, 4}) && !containsPoint(big, Point{7, 4}) {
+		t.Fatalf("radius 1 path %v doesn't go through the wide gap", PathPoints(big))
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+// ComparePaths - first index where a and b stop visiting the same world
+// positions. When one path is a prefix of the other that's the length of the
+// shorter one. Identical paths give false, -1.
+func ComparePaths(a []*Cell, b []*Cell) (diverged bool, atIndex int) {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].World() != b[i].World() {
+			return true, i
+		}
+	}
+
+	if len(a) != len(b) {
+		return true, min(len(a), len(b))
+	}
+
+	return false, -1
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestComparePaths(t *testing.T) {
+	grid := NewGrid(4, 2)
+	row := grid[0]
+
+	cases := []struct {
+		name     string
+		a, b     []*Cell
+		diverged bool
+		at       int
+	}{
+		{"identical", []*Cell{row[0], row[1], row[2]}, []*Cell{row[0], row[1], row[2]}, false, -1},
+		{"different start", []*Cell{grid[1][0], row[1]}, []*Cell{row[0], row[1]}, true, 0},
+		{"split halfway", []*Cell{row[0], row[1], row[2]}, []*Cell{row[0], row[1], grid[1][2]}, true, 2},
+		{"longer", []*Cell{row[0], row[1]}, []*Cell{row[0], row[1], row[2], row[3]}, true, 2},
+		{"both empty", nil, nil, false, -1},
+	}
+
+	for _, c := range cases {
+		if diverged, at := ComparePaths(c.a, c.b); diverged != c.diverged || at != c.at {
+			t.Errorf("%s: got %v, %d, want %v, %d", c.name, diverged, at, c.diverged, c.at)
+		}
+	}
+
+	// Copies of the same cells count as the same
+	if diverged, _ := ComparePaths(grid[0], grid.Clone()[0]); diverged {
+		t.Error("cloned cells count as a different path")
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+// Components - connected regions of walkable cells under the movement rules
+type Components struct {
+	Count int
+
+	// labels - region of every cell [row][column], -1 for walls
+	labels [][]int
+}
+
+// ConnectedComponents - flood fills the walkable cells into regions
+func (g Grid) ConnectedComponents() *Components {
+	return labelComponents(g, func(cell *Cell) []*Cell {
+		neighbours, _ := GetNeighbourCells(g, cell)
+		return neighbours
+	})
+}
+
+// connectedComponents - ConnectedComponents under the movement rules of s. A
+// move MaxClimb only allows one way links the cells both ways, so cells in
+// different regions still can't reach each other.
+func (s *Solver) connectedComponents() *Components {
+	var neighbourBuf [8]*Cell
+	var costBuf [8]int
+
+	return labelComponents(s.Grid, func(cell *Cell) []*Cell {
+		neighbours, _ := s.neighbours(cell, neighbourBuf[:0], costBuf[:0])
+		return neighbours
+	})
+}
+
+// labelComponents - flood fills the walkable cells of g into regions, moving
+// from a cell to the cells neighbours returns for it
+func labelComponents(g Grid, neighbours func(cell *Cell) []*Cell) *Components {
+	c := &Components{labels: make([][]int, len(g))}
+	for y := range g {
+		c.labels[y] = make([]int, len(g[y]))
+
+		for x := range c.labels[y] {
+			c.labels[y][x] = -1
+		}
+	}
+
+	for y := range g {
+		for x := range g[y] {
+			if g[y][x].State == DISABLED || c.labels[y][x] != -1 {
+				continue
+			}
+
+			c.labels[y][x] = c.Count
+			stack := []*Cell{g[y][x]}
+
+			for len(stack) > 0 {
+				cell := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+
+				for _, neighbour := range neighbours(cell) {
+					if c.labels[neighbour.Row][neighbour.Col] == -1 {
+						c.labels[neighbour.Row][neighbour.Col] = c.Count
+						stack = append(stack, neighbour)
+					}
+				}
+			}
+
+			c.Count++
+		}
+	}
+
+	return c
+}
+
+// Label - region of the cell at p, -1 for walls and points off the grid
+func (c *Components) Label(p Point) int {
+	if p.Y < 0 || p.Y >= len(c.labels) || p.X < 0 || p.X >= len(c.labels[p.Y]) {
+		return -1
+	}
+
+	return c.labels[p.Y][p.X]
+}
+
+// Connected - whether a path between a and b can exist at all
+func (c *Components) Connected(a Point, b Point) bool {
+	label := c.Label(a)
+
+	return label != -1 && label == c.Label(b)
+}
+
+// WithComponents - check start and target are in the same region before
+// searching, so unreachable targets fail without expanding anything. The regions
+// follow the solver's movement rules and edge walls, and are worked out again
+// after walls change through SetWalkable or AddEdgeWall.
+func WithComponents() Option {
+	return func(s *Solver) {
+		s.useComponents = true
+		s.components = nil
+	}
+}
+
+// LabelRooms - room of every cell [row][column] numbered from 0, -1 for walls,
+// and the number of rooms. Same regions as ConnectedComponents.
+func (g Grid) LabelRooms() ([][]int, int) {
+	c := g.ConnectedComponents()
+
+	return c.labels, c.Count
+}
+
+// DeadRegions - walkable cells that can't be reached from start and can't reach
+// target either, row by row, e.g. pockets a level can do without
+func DeadRegions(grid Grid, start Point, target Point) []Point {
+	fromStart := grid.DistanceField(start)
+	toTarget := grid.DistanceField(target)
+
+	var dead []Point
+
+	for y := range grid {
+		for x := range grid[y] {
+			if grid[y][x].Walkable() && fromStart[y][x] == -1 && toTarget[y][x] == -1 {
+				dead = append(dead, Point{x, y})
+			}
+		}
+	}
+
+	return dead
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestComponentsTwoRooms(t *testing.T) {
+	grid := parseGrid(
+		"...#...",
+		"...#...",
+		"...#...",
+	)
+
+	c := grid.ConnectedComponents()
+	if c.Count != 2 {
+		t.Fatalf("got %d regions, want 2", c.Count)
+	}
+	if c.Connected(Point{0, 0}, Point{6, 2}) || !c.Connected(Point{0, 0}, Point{2, 2}) {
+		t.Fatal("regions don't match the rooms")
+	}
+
+	s := NewSolver(grid, WithComponents())
+
+	_, err := s.FindPath(Point{0, 0}, Point{6, 2})
+	if !errors.Is(err, ErrNoPath) {
+		t.Fatalf("got %v, want ErrNoPath", err)
+	}
+	if s.Stats.Expansions != 0 {
+		t.Fatalf("expanded %d cells, want none", s.Stats.Expansions)
+	}
+
+	// A door between the rooms joins them up
+	s.SetWalkable(3, 1, true)
+
+	if _, err := s.FindPath(Point{0, 0}, Point{6, 2}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestComponentsFollowMovement(t *testing.T) {
+	// Joined only through a corner
+	grid := parseGrid(
+		"..#",
+		"..#",
+		"##.",
+	)
+
+	if !grid.ConnectedComponents().Connected(Point{0, 0}, Point{2, 2}) {
+		t.Fatal("corner doesn't join the cells with diagonal moves")
+	}
+
+	s := NewSolver(grid, WithMovement(CARDINAL), WithComponents())
+
+	if _, err := s.FindPath(Point{0, 0}, Point{2, 2}); !errors.Is(err, ErrNoPath) || s.Stats.Expansions != 0 {
+		t.Fatalf("got %v after %d expansions, want ErrNoPath without expanding", err, s.Stats.Expansions)
+	}
+}
+
+func TestLabelRoomsThreeRooms(t *testing.T) {
+	grid := parseGrid(
+		"..#..#.",
+		"..#..#.",
+		"###..#.",
+	)
+
+	labels, count := grid.LabelRooms()
+	if count != 3 {
+		t.Fatalf("got %d rooms, want 3", count)
+	}
+
+	want := [][]int{
+		{0, 0, -1, 1, 1, -1, 2},
+		{0, 0, -1, 1, 1, -1, 2},
+		{-1, -1, -1, 1, 1, -1, 2},
+	}
+	if !reflect.DeepEqual(labels, want) {
+		t.Fatalf("got labels %v, want %v", labels, want)
+	}
+}
+
+func TestDeadRegionsIsolatedPocket(t *testing.T) {
+	grid := parseGrid(
+		"....#...",
+		"....#...",
+		"#####..#",
+		"..#.....",
+		"..#.....",
+	)
+
+	// The pockets top left and bottom left are closed off, the rest is one region
+	dead := DeadRegions(grid, Point{5, 0}, Point{7, 4})
+	want := []Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {0, 1}, {1, 1}, {2, 1}, {3, 1}, {0, 3}, {1, 3}, {0, 4}, {1, 4}}
+
+	if !reflect.DeepEqual(dead, want) {
+		t.Fatalf("dead cells %v, want %v", dead, want)
+	}
+
+	if dead := DeadRegions(NewGrid(4, 4), Point{0, 0}, Point{3, 3}); len(dead) != 0 {
+		t.Fatalf("open grid has dead cells %v", dead)
+	}
+}
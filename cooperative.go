@@ -0,0 +1,171 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// AgentQuery - where one agent starts and wants to go
+type AgentQuery struct {
+	Start  Point
+	Target Point
+}
+
+// FindPathsCooperative - paths for several agents that never share a cell at
+// the same time or swap places, planned one agent at a time in the order given,
+// each avoiding the ones before it (cooperative A*). Time runs in steps of one
+// move, diagonal moves included, and an agent may wait a step where it is, so
+// path i holds agent i's cell at every step from 0 until it arrives. Agents stay
+// on their target once there. Fails when an agent can't arrive within maxT
+// steps around the agents planned before it.
+func FindPathsCooperative(grid Grid, agents []AgentQuery, maxT int) ([][]*Cell, error) {
+	r := &reservations{cells: make(map[spaceTime]bool), moves: make(map[spaceTime]*Cell), parked: make(map[*Cell]int)}
+	paths := make([][]*Cell, len(agents))
+
+	for i, agent := range agents {
+		path, err := r.findPath(grid, agent, maxT)
+		if err != nil {
+			return nil, fmt.Errorf("agent %d: %w", i, err)
+		}
+
+		r.reserve(path)
+		paths[i] = path
+	}
+
+	return paths, nil
+}
+
+// spaceTime - a cell at a time step
+type spaceTime struct {
+	cell *Cell
+	t    int
+}
+
+// reservations - what the agents planned so far take up
+type reservations struct {
+	// cells - cells taken at each step
+	cells map[spaceTime]bool
+
+	// moves - cell the agent in a cell at t moves to for t+1, to rule out swaps
+	moves map[spaceTime]*Cell
+
+	// parked - step from which an agent sits on its target for good
+	parked map[*Cell]int
+}
+
+func (r *reservations) taken(cell *Cell, t int) bool {
+	if since, ok := r.parked[cell]; ok && t >= since {
+		return true
+	}
+
+	return r.cells[spaceTime{cell, t}]
+}
+
+// swaps - whether moving from one cell to another between t and t+1 runs head
+// on into an agent making the opposite move
+func (r *reservations) swaps(from *Cell, to *Cell, t int) bool {
+	return r.moves[spaceTime{to, t}] == from
+}
+
+// free - whether nobody passes through cell from step t on, so an agent can stop
+// there
+func (r *reservations) free(cell *Cell, t int, maxT int) bool {
+	for ; t <= maxT; t++ {
+		if r.taken(cell, t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *reservations) reserve(path []*Cell) {
+	for t, cell := range path {
+		r.cells[spaceTime{cell, t}] = true
+
+		if t+1 < len(path) {
+			r.moves[spaceTime{cell, t}] = path[t+1]
+		}
+	}
+
+	r.parked[path[len(path)-1]] = len(path) - 1
+}
+
+// findPath - A* over cells and time steps around the reservations
+func (r *reservations) findPath(grid Grid, agent AgentQuery, maxT int) ([]*Cell, error) {
+	start, target := agent.Start, agent.Target
+	if !grid.InBounds(start.X, start.Y) || !grid.InBounds(target.X, target.Y) {
+		return nil, ErrOutOfBounds
+	}
+
+	startCell, targetCell := grid[start.Y][start.X], grid[target.Y][target.X]
+	if !startCell.Walkable() || !targetCell.Walkable() || r.taken(startCell, 0) {
+		return nil, &NoPathError{}
+	}
+
+	// Moves left to the target, diagonal ones counting as one
+	steps := func(cell *Cell) int {
+		return max(abs(cell.Col-target.X), abs(cell.Row-target.Y))
+	}
+
+	first := spaceTime{startCell, 0}
+	parent := map[spaceTime]spaceTime{first: first}
+	queue := &spaceTimeQueue{{first, steps(startCell)}}
+	expansions := 0
+
+	for queue.Len() > 0 {
+		cur := heap.Pop(queue).(spaceTimeItem).state
+		expansions++
+
+		if cur.cell == targetCell && r.free(cur.cell, cur.t, maxT) {
+			path := make([]*Cell, cur.t+1)
+			for s := cur; ; s = parent[s] {
+				path[s.t] = s.cell
+				if s == first {
+					return path, nil
+				}
+			}
+		}
+
+		if cur.t >= maxT {
+			continue
+		}
+
+		neighbours, _ := GetNeighbourCells(grid, cur.cell)
+
+		// Waiting is a move to the same cell
+		for _, cell := range append(neighbours, cur.cell) {
+			next := spaceTime{cell, cur.t + 1}
+
+			if _, seen := parent[next]; seen || r.taken(cell, next.t) || r.swaps(cur.cell, cell, cur.t) {
+				continue
+			}
+
+			parent[next] = cur
+			heap.Push(queue, spaceTimeItem{next, next.t + steps(cell)})
+		}
+	}
+
+	return nil, &NoPathError{Expansions: expansions}
+}
+
+type spaceTimeItem struct {
+	state    spaceTime
+	priority int
+}
+
+// spaceTimeQueue - min-heap of states by priority, for container/heap
+type spaceTimeQueue []spaceTimeItem
+
+func (q spaceTimeQueue) Len() int            { return len(q) }
+func (q spaceTimeQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q spaceTimeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *spaceTimeQueue) Push(x interface{}) { *q = append(*q, x.(spaceTimeItem)) }
+
+func (q *spaceTimeQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
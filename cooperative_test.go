@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindPathsCooperativeHeadOn(t *testing.T) {
+	// A corridor with room for one agent to step aside near the east end
+	grid := parseGrid(
+		"#####.#",
+		".......",
+	)
+	agents := []AgentQuery{{Point{0, 1}, Point{6, 1}}, {Point{6, 1}, Point{0, 1}}}
+
+	paths, err := FindPathsCooperative(grid, agents, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Planned first, the first agent goes straight through
+	if len(paths[0]) != 7 {
+		t.Fatalf("first agent took %v, want straight along the corridor", PathPoints(paths[0]))
+	}
+
+	waited := false
+	for i, path := range paths {
+		if path[0].Index() != agents[i].Start || path[len(path)-1].Index() != agents[i].Target {
+			t.Fatalf("agent %d went %v", i, PathPoints(path))
+		}
+
+		for step := 1; step < len(path); step++ {
+			if path[step] == path[step-1] {
+				waited = true
+			} else if abs(path[step].Col-path[step-1].Col) > 1 || abs(path[step].Row-path[step-1].Row) > 1 {
+				t.Fatalf("agent %d jumps at step %d: %v", i, step, PathPoints(path))
+			}
+		}
+	}
+
+	if !waited {
+		t.Fatalf("nobody waited: %v and %v", PathPoints(paths[0]), PathPoints(paths[1]))
+	}
+
+	// Never in the same cell at once nor swapping places, agents stay on their
+	// target once there
+	at := func(path []*Cell, step int) *Cell {
+		return path[min(step, len(path)-1)]
+	}
+
+	for step := 0; step < max(len(paths[0]), len(paths[1])); step++ {
+		a, b := at(paths[0], step), at(paths[1], step)
+		if a == b {
+			t.Fatalf("both agents in %v at step %d", a.Index(), step)
+		}
+
+		if step > 0 && a == at(paths[1], step-1) && b == at(paths[0], step-1) {
+			t.Fatalf("agents swap places at step %d", step)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"sort"
+)
+
+// CriticalCells - cells every shortest path from start to target goes through,
+// start and target included, in path order. nil when there is no path.
+func CriticalCells(grid Grid, start Point, target Point) []*Cell {
+	if !grid.InBounds(start.X, start.Y) || !grid.InBounds(target.X, target.Y) {
+		return nil
+	}
+
+	fromStart := grid.DistanceField(start)
+	toTarget := grid.DistanceField(target)
+
+	best := fromStart[target.Y][target.X]
+	if best == -1 {
+		return nil
+	}
+
+	// Cells on some shortest path, counted per distance from the start, and the
+	// distances skipped over by moves between them
+	onPath := make(map[int][]*Cell)
+	type jump struct{ from, to int }
+	var jumps []jump
+
+	for y := range grid {
+		for x := range grid[y] {
+			d := fromStart[y][x]
+			if d == -1 || toTarget[y][x] == -1 || d+toTarget[y][x] != best {
+				continue
+			}
+
+			cell := grid[y][x]
+			onPath[d] = append(onPath[d], cell)
+
+			neighbours, costs := GetNeighbourCells(grid, cell)
+			for n, next := range neighbours {
+				if toTarget[next.Row][next.Col] != -1 && d+costs[n]+toTarget[next.Row][next.Col] == best {
+					jumps = append(jumps, jump{d, d + costs[n]})
+				}
+			}
+		}
+	}
+
+	var levels []int
+	for d := range onPath {
+		levels = append(levels, d)
+	}
+	sort.Ints(levels)
+
+	// A level is skipped by a move when it lies strictly between the move's ends
+	skipped := make([]int, len(levels)+1)
+	for _, j := range jumps {
+		lo := sort.SearchInts(levels, j.from+1)
+		hi := sort.SearchInts(levels, j.to)
+		if lo < hi {
+			skipped[lo]++
+			skipped[hi]--
+		}
+	}
+
+	var critical []*Cell
+	running := 0
+
+	for i, d := range levels {
+		running += skipped[i]
+
+		if running == 0 && len(onPath[d]) == 1 {
+			critical = append(critical, onPath[d][0])
+		}
+	}
+
+	return critical
+}
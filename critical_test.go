@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCriticalCellsCorridor(t *testing.T) {
+	grid := parseGrid(
+		"#####.",
+		"......",
+		".#####",
+	)
+	start, target := Point{0, 2}, Point{5, 0}
+
+	path, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One way through, every cell on it is unavoidable
+	critical := CriticalCells(grid, start, target)
+
+	if !reflect.DeepEqual(PathPoints(critical), PathPoints(path)) {
+		t.Fatalf("critical cells %v, want the whole corridor %v", PathPoints(critical), PathPoints(path))
+	}
+}
+
+func TestCriticalCellsOpenRoom(t *testing.T) {
+	critical := CriticalCells(NewGrid(5, 5), Point{0, 0}, Point{4, 2})
+
+	// Plenty of equally short ways across, only the ends are certain
+	if got := PathPoints(critical); len(got) != 2 {
+		t.Fatalf("critical cells %v, want start and target only", got)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+// Crop - copy of the smallest sub-grid holding every walkable cell, plus the
+// offset of its top left corner in g. Cells of the copy keep their world X, Y
+// but get new row and column indices, add the offset to an index of the copy
+// to get back to the index in g.
+func (g Grid) Crop() (cropped Grid, offsetX int, offsetY int) {
+	minX, minY := -1, -1
+	maxX, maxY := -1, -1
+
+	for y := range g {
+		for x := range g[y] {
+			if g[y][x].State == DISABLED {
+				continue
+			}
+
+			if minX == -1 || x < minX {
+				minX = x
+			}
+			if x > maxX {
+				maxX = x
+			}
+			if minY == -1 {
+				minY = y
+			}
+			maxY = y
+		}
+	}
+
+	if minX == -1 {
+		// Nothing walkable, nothing to keep
+		return Grid{}, 0, 0
+	}
+
+	cropped = make(Grid, maxY-minY+1)
+
+	for y := range cropped {
+		cropped[y] = make([]*Cell, maxX-minX+1)
+
+		for x := range cropped[y] {
+			cell := *g[y+minY][x+minX]
+			cell.Row = y
+			cell.Col = x
+
+			cropped[y][x] = &cell
+		}
+	}
+
+	return cropped, minX, minY
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCrop(t *testing.T) {
+	grid := parseGrid(
+		"######",
+		"##..##",
+		"##...#",
+		"######",
+		"######",
+	)
+
+	cropped, offsetX, offsetY := grid.Crop()
+
+	if len(cropped) != 2 || len(cropped[0]) != 3 {
+		t.Fatalf("cropped to %dx%d, want 3x2", len(cropped[0]), len(cropped))
+	}
+
+	if offsetX != 2 || offsetY != 1 {
+		t.Fatalf("offset %d, %d, want 2, 1", offsetX, offsetY)
+	}
+
+	for y := range cropped {
+		for x := range cropped[y] {
+			cell := cropped[y][x]
+
+			if cell.Index() != (Point{x, y}) {
+				t.Fatalf("cell at %d, %d has index %v", x, y, cell.Index())
+			}
+
+			if cell.World() != (Point{x + offsetX, y + offsetY}) {
+				t.Fatalf("cell at %d, %d has world position %v, want %v", x, y, cell.World(), Point{x + offsetX, y + offsetY})
+			}
+
+			if cell.State != grid[y+offsetY][x+offsetX].State {
+				t.Fatalf("cell at %d, %d has state %v, original has %v", x, y, cell.State, grid[y+offsetY][x+offsetX].State)
+			}
+		}
+	}
+
+	// A copy, the original grid keeps its cells
+	cropped[0][0].State = PATH
+	if grid[1][2].State == PATH {
+		t.Fatal("cropping shares cells with the original grid")
+	}
+}
+
+func TestCropNothingWalkable(t *testing.T) {
+	cropped, offsetX, offsetY := parseGrid("##", "##").Crop()
+
+	if len(cropped) != 0 || offsetX != 0 || offsetY != 0 {
+		t.Fatalf("got %d rows at %d, %d, want an empty grid", len(cropped), offsetX, offsetY)
+	}
+}
+
+func TestFindPathOnCroppedGrid(t *testing.T) {
+	grid := parseGrid(
+		"#######",
+		"#######",
+		"###....",
+		"###.#..",
+		"###....",
+	)
+
+	cropped, offsetX, offsetY := grid.Crop()
+
+	// Search by index in the cropped grid
+	path, err := FindPath(cropped, Point{0, 1}, Point{2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cell := range path {
+		index, world := cell.Index(), cell.World()
+
+		if world != (Point{index.X + offsetX, index.Y + offsetY}) {
+			t.Fatalf("cell at index %v has world position %v, want %v", index, world, Point{index.X + offsetX, index.Y + offsetY})
+		}
+
+		if !grid[world.Y][world.X].Walkable() {
+			t.Fatalf("path %v runs through a wall of the full grid at %v", PathPoints(path), world)
+		}
+	}
+
+	if first := path[0].World(); first != (Point{3, 3}) {
+		t.Fatalf("path starts at world %v, want (3, 3)", first)
+	}
+}
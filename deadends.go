@@ -0,0 +1,28 @@
+package main
+
+// DeadEnds - walkable cells with exactly one walkable neighbour under the
+// default movement rules
+func (g Grid) DeadEnds() []Point {
+	return NewSolver(g).DeadEnds()
+}
+
+// DeadEnds - walkable cells with exactly one neighbour under the solver's
+// movement rules, e.g. the ends of corridors with CARDINAL movement
+func (s *Solver) DeadEnds() []Point {
+	var ends []Point
+
+	for y := range s.Grid {
+		for x := range s.Grid[y] {
+			cell := s.Grid[y][x]
+			if !cell.Walkable() {
+				continue
+			}
+
+			if neighbours, _ := s.neighbours(cell, nil, nil); len(neighbours) == 1 {
+				ends = append(ends, cell.Index())
+			}
+		}
+	}
+
+	return ends
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeadEndsMaze(t *testing.T) {
+	grid := parseGrid(
+		"...#.",
+		".#.#.",
+		".#...",
+		".####",
+	)
+
+	ends := NewSolver(grid, WithMovement(CARDINAL)).DeadEnds()
+	if want := []Point{{4, 0}, {0, 3}}; !reflect.DeepEqual(ends, want) {
+		t.Fatalf("got %v, want %v", ends, want)
+	}
+}
+
+func TestDeadEndsOpenArea(t *testing.T) {
+	if ends := NewGrid(3, 3).DeadEnds(); len(ends) != 0 {
+		t.Fatalf("open area has dead ends %v", ends)
+	}
+}
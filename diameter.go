@@ -0,0 +1,71 @@
+package main
+
+// Diameter - the two walkable cells furthest apart by path cost, and that cost.
+// Runs a distance field from every walkable cell, use DiameterApprox on big grids.
+func (g Grid) Diameter() (a Point, b Point, cost int) {
+	cost = -1
+
+	for y := range g {
+		for x := range g[y] {
+			if g[y][x].State == DISABLED {
+				continue
+			}
+
+			from := Point{x, y}
+			if to, dist := g.furthest(from); dist > cost {
+				a, b, cost = from, to, dist
+			}
+		}
+	}
+
+	if cost == -1 {
+		return Point{}, Point{}, 0
+	}
+
+	return a, b, cost
+}
+
+// DiameterApprox - estimate of Diameter from sweeps distance fields: each sweep
+// starts from the cell furthest from the previous one. Exact on corridors and
+// trees, and never over the real diameter.
+func (g Grid) DiameterApprox(sweeps int) (a Point, b Point, cost int) {
+	first, ok := NearestWalkable(g, Point{0, 0})
+	if !ok {
+		return Point{}, Point{}, 0
+	}
+
+	from := first.Index()
+	cost = -1
+
+	for i := 0; i < sweeps; i++ {
+		to, dist := g.furthest(from)
+		if dist <= cost {
+			break
+		}
+
+		a, b, cost = from, to, dist
+		from = to
+	}
+
+	if cost == -1 {
+		cost = 0
+	}
+
+	return a, b, cost
+}
+
+// furthest - reachable cell with the costliest path from from
+func (g Grid) furthest(from Point) (Point, int) {
+	dist := g.DistanceField(from)
+	best, bestDist := from, 0
+
+	for y := range dist {
+		for x := range dist[y] {
+			if dist[y][x] > bestDist {
+				best, bestDist = Point{x, y}, dist[y][x]
+			}
+		}
+	}
+
+	return best, bestDist
+}
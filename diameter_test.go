@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiameterWindingCorridor(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		"####.",
+		".....",
+		".####",
+		".....",
+	)
+	ends := map[Point]bool{{0, 0}: true, {4, 4}: true}
+
+	a, b, cost := grid.Diameter()
+	if !ends[a] || !ends[b] || a == b {
+		t.Fatalf("diameter runs from %v to %v, want the corridor's ends", a, b)
+	}
+
+	s := NewSolver(grid)
+	if _, err := s.FindPath(a, b); err != nil || s.Stats.Cost != cost {
+		t.Fatalf("diameter cost %d, path between its ends costs %d (%v)", cost, s.Stats.Cost, err)
+	}
+
+	approxA, approxB, approxCost := grid.DiameterApprox(4)
+	if !ends[approxA] || !ends[approxB] || approxCost != cost {
+		t.Fatalf("approximation runs from %v to %v costing %d, want the corridor's ends costing %d",
+			approxA, approxB, approxCost, cost)
+	}
+}
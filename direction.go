@@ -0,0 +1,106 @@
+package main
+
+// Direction - heading of a move between neighbouring cells. NORTH is "top" in
+// GetNeighbourCells (row + 1) and EAST is "right" (column + 1).
+type Direction int
+
+const (
+	NONE Direction = iota // no move yet, e.g. at the start
+	NORTH
+	NORTHEAST
+	EAST
+	SOUTHEAST
+	SOUTH
+	SOUTHWEST
+	WEST
+	NORTHWEST
+)
+
+// directionOf - heading of the move from one cell to a neighbour
+func directionOf(from *Cell, to *Cell) Direction {
+	dx := sign(to.Col - from.Col)
+	dy := sign(to.Row - from.Row)
+
+	switch {
+	case dx == 0 && dy > 0:
+		return NORTH
+	case dx > 0 && dy > 0:
+		return NORTHEAST
+	case dx > 0 && dy == 0:
+		return EAST
+	case dx > 0 && dy < 0:
+		return SOUTHEAST
+	case dx == 0 && dy < 0:
+		return SOUTH
+	case dx < 0 && dy < 0:
+		return SOUTHWEST
+	case dx < 0 && dy == 0:
+		return WEST
+	case dx < 0 && dy > 0:
+		return NORTHWEST
+	}
+
+	return NONE
+}
+
+// TurnSteps - size of the turn from d to to in eighths of a full turn, 0 to 4.
+// 0 when either direction is NONE.
+func (d Direction) TurnSteps(to Direction) int {
+	if d == NONE || to == NONE {
+		return 0
+	}
+
+	steps := int(to-d+8) % 8
+	if steps > 4 {
+		steps = 8 - steps
+	}
+
+	return steps
+}
+
+// ProportionalTurnCost - TurnCostFunc charging perStep for every 45 degrees turned
+func ProportionalTurnCost(perStep int) func(fromDir Direction, toDir Direction) int {
+	return func(fromDir Direction, toDir Direction) int {
+		return perStep * fromDir.TurnSteps(toDir)
+	}
+}
+
+// WithTurnWeight - charge w on top of the move cost for every change of
+// heading, so the search minimises cost + w*turns. FindPath then searches over
+// every cell and heading it can be entered with instead of one route per cell,
+// which finds the best trade-off and charges TurnCostFunc exactly too, for a few
+// times the work. Budgets, early stops, weighting and MaxReopens work as in the
+// usual search. Fatigue and MaxDiagonalRun, which need more of the route than
+// its last heading, are left out, as are NewOpenSet and PreferDiagonals, since
+// the search keeps its own queue. LazyHeuristic makes no difference to it, and a
+// Stepper doesn't use it.
+func WithTurnWeight(w int) Option {
+	return func(s *Solver) {
+		s.TurnWeight = w
+	}
+}
+
+// PathTurns - number of times path changes heading, diagonal moves counting as
+// headings of their own
+func PathTurns(path []*Cell) int {
+	turns := 0
+
+	for i := 2; i < len(path); i++ {
+		if directionOf(path[i-2], path[i-1]) != directionOf(path[i-1], path[i]) {
+			turns++
+		}
+	}
+
+	return turns
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// sharpestTurn - biggest turn along path, in eighths of a full turn
+func sharpestTurn(path []*Cell) int {
+	sharpest := 0
+	for i := 2; i < len(path); i++ {
+		sharpest = max(sharpest, directionOf(path[i-2], path[i-1]).TurnSteps(directionOf(path[i-1], path[i])))
+	}
+
+	return sharpest
+}
+
+func TestTurnCostFuncGentleTurns(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		"....#",
+		"...#.",
+		"....#",
+	)
+	start, target := Point{3, 3}, Point{4, 0}
+
+	zigzag, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sharpestTurn(zigzag) < 2 {
+		t.Fatalf("plain path %v has no right-angle turn", PathPoints(zigzag))
+	}
+
+	s := NewSolver(grid)
+	s.TurnCostFunc = ProportionalTurnCost(8)
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	if path[len(path)-1].Index() != target {
+		t.Fatalf("path %v doesn't reach the target", PathPoints(path))
+	}
+	if turn := sharpestTurn(path); turn > 1 {
+		t.Fatalf("path %v still turns %d eighths at once", PathPoints(path), turn)
+	}
+}
+
+func TestTurnSteps(t *testing.T) {
+	cases := []struct {
+		from, to Direction
+		steps    int
+	}{
+		{NORTH, NORTH, 0},
+		{NORTH, NORTHEAST, 1},
+		{NORTH, NORTHWEST, 1},
+		{EAST, NORTH, 2},
+		{NORTHEAST, SOUTH, 3},
+		{WEST, EAST, 4},
+		{NONE, EAST, 0},
+	}
+
+	for _, c := range cases {
+		if steps := c.from.TurnSteps(c.to); steps != c.steps {
+			t.Errorf("%v to %v: %d steps, want %d", c.from, c.to, steps, c.steps)
+		}
+	}
+}
+
+func TestDirectionCostTailwind(t *testing.T) {
+	grid := NewGrid(5, 5)
+	s := NewSolver(grid)
+
+	path, err := s.FindPath(Point{0, 0}, Point{4, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 5 {
+		t.Fatalf("calm path %v isn't the diagonal", PathPoints(path))
+	}
+
+	// Eastward moves cost 2, so the long way along the bottom then up beats
+	// four diagonals at 14
+	s.DirectionCost = map[Direction]int{EAST: -8}
+
+	path, err = s.FindPath(Point{0, 0}, Point{4, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 9 || s.Stats.Cost != 4*2+4*10 {
+		t.Fatalf("tailwind path %v costs %d, want 8 moves costing 48", PathPoints(path), s.Stats.Cost)
+	}
+	for i := 1; i < len(path); i++ {
+		if dir := directionOf(path[i-1], path[i]); dir != EAST && dir != NORTH {
+			t.Fatalf("tailwind path %v moves %v", PathPoints(path), dir)
+		}
+	}
+}
+
+func TestPathTurns(t *testing.T) {
+	grid := NewGrid(4, 4)
+
+	l := []*Cell{grid[0][0], grid[0][1], grid[0][2], grid[1][2], grid[2][2]}
+	if turns := PathTurns(l); turns != 1 {
+		t.Fatalf("L-shaped path turns %d times, want 1", turns)
+	}
+
+	zigzag := []*Cell{grid[0][0], grid[0][1], grid[1][1], grid[1][2], grid[2][2], grid[2][3]}
+	if turns := PathTurns(zigzag); turns != 4 {
+		t.Fatalf("zigzag turns %d times, want 4", turns)
+	}
+
+	if turns := PathTurns(grid[0]); turns != 0 {
+		t.Fatalf("straight path turns %d times", turns)
+	}
+}
+
+func TestWithTurnWeightFewerTurns(t *testing.T) {
+	// Cheap cells only along a staircase from corner to corner
+	grid := NewGrid(6, 6)
+	for y := range grid {
+		for x := range grid[y] {
+			if x != y && x != y+1 {
+				grid[y][x].Weight = 1
+			}
+		}
+	}
+	start, target := Point{0, 0}, Point{5, 5}
+
+	plain := NewSolver(grid, WithMovement(CARDINAL))
+	stairs, err := plain.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver(grid, WithMovement(CARDINAL), WithTurnWeight(10))
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	// Stats.Cost has the turns in it, take them back out for the movement cost
+	moveCost := s.Stats.Cost - 10*PathTurns(path)
+	if PathTurns(path) >= PathTurns(stairs) || moveCost <= plain.Stats.Cost || moveCost > plain.Stats.Cost+10 {
+		t.Fatalf("turn weighted path %v has %d turns and moves costing %d, the cheapest %d turns and %d",
+			PathPoints(path), PathTurns(path), moveCost, PathTurns(stairs), plain.Stats.Cost)
+	}
+}
+
+func TestWithTurnWeightByHeading(t *testing.T) {
+	grid := parseGrid(
+		"....",
+		"#...",
+		"...#",
+	)
+	start, target := Point{2, 1}, Point{0, 2}
+
+	// Down and then straight along the bottom is 3 moves and one turn. Keeping
+	// one heading per cell settles for a route with two turns, costing 60.
+	s := NewSolver(grid, WithMovement(CARDINAL), WithTurnWeight(15))
+	if _, err := s.FindPath(start, target); err != nil || s.Stats.Cost != 45 {
+		t.Fatalf("got cost %d (%v), want 45", s.Stats.Cost, err)
+	}
+
+	// A TurnCostFunc of the caller's own is kept and charged on top
+	gentle := func(s *Solver) { s.TurnCostFunc = ProportionalTurnCost(4) }
+	s = NewSolver(grid, WithMovement(CARDINAL), gentle, WithTurnWeight(15))
+	if _, err := s.FindPath(start, target); err != nil || s.Stats.Cost != 53 {
+		t.Fatalf("got cost %d (%v) with TurnCostFunc, want 53", s.Stats.Cost, err)
+	}
+}
+
+func TestWithTurnWeightBudget(t *testing.T) {
+	grid := NewGrid(30, 30)
+	start, target := Point{0, 0}, Point{29, 29}
+
+	s := NewSolver(grid, WithTurnWeight(10))
+	s.MaxSteps = 20
+	if _, err := s.FindPath(start, target); !errors.Is(err, ErrBudget) {
+		t.Fatalf("got %v, want ErrBudget", err)
+	}
+
+	if s.Stats.Expansions > s.MaxSteps {
+		t.Fatalf("expanded %d states, budget was %d", s.Stats.Expansions, s.MaxSteps)
+	}
+}
+
+func TestWithTurnWeightEarlyStop(t *testing.T) {
+	grid := NewGrid(30, 30)
+	start, target := Point{0, 0}, Point{29, 29}
+
+	s := NewSolver(grid, WithTurnWeight(10))
+	s.GoodEnoughH = 60
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := path[len(path)-1].Index()
+	if last == target || s.heuristic(last, target) > s.GoodEnoughH {
+		t.Fatalf("path ends at %v, want short of the target within %d", last, s.GoodEnoughH)
+	}
+
+	if s.Stats.Optimal {
+		t.Fatal("stopping short reported as optimal")
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+// DisjointPaths - k paths from start to target that share no cells besides the
+// two ends, found one after the other with the cells of earlier paths blocked.
+// Fails with ErrNoPath when fewer than k such paths are found; taking them
+// greedily like this can miss some layouts that do fit k.
+func DisjointPaths(grid Grid, start Point, target Point, k int) ([][]*Cell, error) {
+	used := make(map[Point]bool)
+	direct := false
+
+	s := NewSolver(grid)
+	s.NeighbourFilter = func(from *Cell, to *Cell) bool {
+		if direct && from.Index() == start && to.Index() == target {
+			// Start and target are neighbours, that move was taken already
+			return false
+		}
+
+		return !used[to.Index()]
+	}
+
+	var paths [][]*Cell
+
+	for len(paths) < k {
+		path, err := s.FindPath(start, target)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(path) == 1 {
+			// Start is the target, nowhere else to go
+			paths = append(paths, path)
+			continue
+		}
+
+		if len(path) == 2 {
+			direct = true
+		}
+
+		for _, cell := range path[1 : len(path)-1] {
+			used[cell.Index()] = true
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDisjointPathsTwoCorridors(t *testing.T) {
+	grid := parseGrid(
+		".......",
+		".#####.",
+		".......",
+	)
+	start, target := Point{0, 1}, Point{6, 1}
+
+	paths, err := DisjointPaths(grid, start, target, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("got %d paths, want 2", len(paths))
+	}
+
+	seen := make(map[Point]int)
+	for i, path := range paths {
+		checkContiguous(t, path)
+
+		for _, cell := range path[1 : len(path)-1] {
+			if other, ok := seen[cell.Index()]; ok {
+				t.Fatalf("paths %d and %d share %v", other, i, cell.Index())
+			}
+			seen[cell.Index()] = i
+		}
+	}
+
+	if _, err := DisjointPaths(grid, start, target, 3); !errors.Is(err, ErrNoPath) {
+		t.Fatalf("got %v for three paths through two corridors, want ErrNoPath", err)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"container/heap"
+)
+
+// DistanceField - cost of the cheapest path from the nearest source to every cell,
+// indexed [row][column], -1 for cells no source can reach
+func (g Grid) DistanceField(sources ...Point) [][]int {
+	dist, _ := g.sourceField(sources)
+
+	return dist
+}
+
+// VoronoiRegions - index of the source each cell [row][column] is cheapest to
+// reach from, -1 for cells no source reaches. Ties go to the lower index.
+func VoronoiRegions(grid Grid, sources []Point) [][]int {
+	_, owner := grid.sourceField(sources)
+
+	return owner
+}
+
+// sourceField - distance field plus the index of the source each distance is from
+func (g Grid) sourceField(sources []Point) (dist [][]int, owner [][]int) {
+	dist = make([][]int, len(g))
+	owner = make([][]int, len(g))
+
+	for y := range g {
+		dist[y] = make([]int, len(g[y]))
+		owner[y] = make([]int, len(g[y]))
+
+		for x := range dist[y] {
+			dist[y][x] = -1
+			owner[y][x] = -1
+		}
+	}
+
+	queue := &distanceQueue{}
+
+	for i, p := range sources {
+		if !g.InBounds(p.X, p.Y) || g[p.Y][p.X].State == DISABLED || owner[p.Y][p.X] != -1 {
+			continue
+		}
+
+		dist[p.Y][p.X] = 0
+		owner[p.Y][p.X] = i
+		heap.Push(queue, distanceItem{g[p.Y][p.X], 0, i})
+	}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(distanceItem)
+		row, col := item.cell.Row, item.cell.Col
+		if item.dist != dist[row][col] || item.source != owner[row][col] {
+			// Stale entry, the cell was reached cheaper since
+			continue
+		}
+
+		neighbours, costs := GetNeighbourCells(g, item.cell)
+
+		for n, neighbour := range neighbours {
+			newDist := item.dist + costs[n]
+			oldDist := dist[neighbour.Row][neighbour.Col]
+
+			if oldDist == -1 || newDist < oldDist ||
+				(newDist == oldDist && item.source < owner[neighbour.Row][neighbour.Col]) {
+				dist[neighbour.Row][neighbour.Col] = newDist
+				owner[neighbour.Row][neighbour.Col] = item.source
+				heap.Push(queue, distanceItem{neighbour, newDist, item.source})
+			}
+		}
+	}
+
+	return dist, owner
+}
+
+// ShortestPathTree - cell each cell is entered from on a cheapest path from
+// root, by grid index. Root is its own parent, cells root can't reach are left
+// out.
+func ShortestPathTree(grid Grid, root Point) map[Point]Point {
+	parent := make(map[Point]Point)
+	if !grid.InBounds(root.X, root.Y) || grid[root.Y][root.X].State == DISABLED {
+		return parent
+	}
+
+	dist := map[Point]int{root: 0}
+	parent[root] = root
+
+	queue := &distanceQueue{{cell: grid[root.Y][root.X]}}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(distanceItem)
+		if item.dist != dist[item.cell.Index()] {
+			// Stale entry, the cell was reached cheaper since
+			continue
+		}
+
+		neighbours, costs := GetNeighbourCells(grid, item.cell)
+
+		for n, neighbour := range neighbours {
+			p := neighbour.Index()
+			newDist := item.dist + costs[n]
+
+			if oldDist, seen := dist[p]; !seen || newDist < oldDist {
+				dist[p] = newDist
+				parent[p] = item.cell.Index()
+				heap.Push(queue, distanceItem{cell: neighbour, dist: newDist})
+			}
+		}
+	}
+
+	return parent
+}
+
+type distanceItem struct {
+	cell   *Cell
+	dist   int
+	source int
+}
+
+// distanceQueue - min-heap of cells by distance, for container/heap
+type distanceQueue []distanceItem
+
+func (q distanceQueue) Len() int            { return len(q) }
+func (q distanceQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q distanceQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(x interface{}) { *q = append(*q, x.(distanceItem)) }
+
+func (q *distanceQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestVoronoiRegionsDividingLine(t *testing.T) {
+	grid := parseGrid(
+		".........",
+		".........",
+		"....#....",
+	)
+
+	regions := VoronoiRegions(grid, []Point{{0, 1}, {8, 1}})
+
+	for y := range regions {
+		for x := range regions[y] {
+			want := 0
+			switch {
+			case grid[y][x].State == DISABLED:
+				want = -1
+			case x > 4:
+				want = 1
+			}
+
+			// Column 4 is as far from both, ties go to the first source
+			if regions[y][x] != want {
+				t.Fatalf("cell %d, %d belongs to %d, want %d", x, y, regions[y][x], want)
+			}
+		}
+	}
+}
+
+func TestVoronoiRegionsUnreachable(t *testing.T) {
+	grid := parseGrid(
+		"..#.",
+		"..#.",
+	)
+
+	regions := VoronoiRegions(grid, []Point{{0, 0}})
+
+	if regions[0][3] != -1 || regions[1][1] != 0 {
+		t.Fatalf("got %v, want the right column unassigned", regions)
+	}
+}
+
+func TestShortestPathTreeReachesRoot(t *testing.T) {
+	grid := parseGrid(
+		"......#.",
+		".####.#.",
+		"......#.",
+	)
+	root := Point{0, 0}
+
+	tree := ShortestPathTree(grid, root)
+	if tree[root] != root {
+		t.Fatalf("root's parent is %v, want itself", tree[root])
+	}
+
+	dist := grid.DistanceField(root)
+
+	for y := range grid {
+		for x := range grid[y] {
+			p := Point{x, y}
+			_, ok := tree[p]
+			if ok != (dist[y][x] != -1) {
+				t.Fatalf("%v in tree: %v, reachable: %v", p, ok, dist[y][x] != -1)
+			}
+			if !ok {
+				continue
+			}
+
+			// Parents lead back to the root, each one a step cheaper
+			for steps := 0; p != root; steps++ {
+				parent := tree[p]
+				if steps > len(tree) || dist[parent.Y][parent.X] >= dist[p.Y][p.X] {
+					t.Fatalf("parents from (%d, %d) don't lead to the root", x, y)
+				}
+				p = parent
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+// Sides - set of the sides of a cell, NORTH being row + 1 like Direction
+type Sides uint8
+
+const (
+	NorthSide Sides = 1 << iota
+	EastSide
+	SouthSide
+	WestSide
+)
+
+// AddEdgeWall - puts a thin wall between the cells at a and b, which share a
+// side, blocking moves between them but leaving both open. Marked on the side
+// of each cell, so GetNeighbourCells and everything built on it see the wall.
+// Does nothing unless a and b are straight neighbours in the grid.
+func (g Grid) AddEdgeWall(a Point, b Point) {
+	if !g.InBounds(a.X, a.Y) || !g.InBounds(b.X, b.Y) || abs(a.X-b.X)+abs(a.Y-b.Y) != 1 {
+		return
+	}
+
+	from, to := g[a.Y][a.X], g[b.Y][b.X]
+	out, in := crossedSides(from, to)
+
+	from.EdgeWalls |= out
+	to.EdgeWalls |= in
+}
+
+// AddEdgeWall - Grid.AddEdgeWall on the solver's grid, forgetting cached paths,
+// regions and clearance
+func (s *Solver) AddEdgeWall(a Point, b Point) {
+	s.Grid.AddEdgeWall(a, b)
+
+	if s.cache != nil {
+		s.cache.clear()
+	}
+
+	// Rebuilt by the next search that needs them
+	s.components = nil
+	s.clearance = nil
+}
+
+// edgeWall - whether a thin wall blocks the move from one cell to a neighbour.
+// A diagonal move is blocked by a wall on any of the four edges around the
+// corner it goes through, and each of those is a side of one of its ends.
+func edgeWall(from *Cell, to *Cell) bool {
+	if from.EdgeWalls == 0 && to.EdgeWalls == 0 {
+		return false
+	}
+
+	if abs(to.Col-from.Col) > 1 || abs(to.Row-from.Row) > 1 {
+		// Not side by side in the grid, e.g. a Neighbours that wraps round
+		return false
+	}
+
+	out, in := crossedSides(from, to)
+
+	return from.EdgeWalls&out != 0 || to.EdgeWalls&in != 0
+}
+
+// crossedSides - sides of from the move to a neighbour heads out through and
+// sides of to it comes in through, two each for a diagonal
+func crossedSides(from *Cell, to *Cell) (Sides, Sides) {
+	var out, in Sides
+
+	switch sign(to.Col - from.Col) {
+	case 1:
+		out, in = EastSide, WestSide
+	case -1:
+		out, in = WestSide, EastSide
+	}
+
+	switch sign(to.Row - from.Row) {
+	case 1:
+		out, in = out|NorthSide, in|SouthSide
+	case -1:
+		out, in = out|SouthSide, in|NorthSide
+	}
+
+	return out, in
+}
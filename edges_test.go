@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEdgeWallForcesDetour(t *testing.T) {
+	grid := NewGrid(3, 3)
+	s := NewSolver(grid)
+
+	// Shut the middle cell off on its left, top and bottom
+	s.AddEdgeWall(Point{0, 1}, Point{1, 1})
+	s.AddEdgeWall(Point{1, 1}, Point{1, 0})
+	s.AddEdgeWall(Point{1, 2}, Point{1, 1})
+
+	path, err := s.FindPath(Point{0, 1}, Point{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	// Round to the right side, the only one left open
+	if len(path) < 4 || path[len(path)-2].Index() != (Point{2, 1}) {
+		t.Fatalf("path %v, want one coming in from the right", PathPoints(path))
+	}
+
+	for i := 1; i < len(path); i++ {
+		if edgeWall(path[i-1], path[i]) {
+			t.Fatalf("path %v crosses an edge wall at %v", PathPoints(path), path[i].Index())
+		}
+	}
+}
+
+func TestEdgeWallGridHelpers(t *testing.T) {
+	grid := NewGrid(4, 3)
+
+	// A thin wall down the middle, open only at the top
+	for y := 0; y < 2; y++ {
+		grid.AddEdgeWall(Point{1, y}, Point{2, y})
+	}
+
+	neighbours, _ := GetNeighbourCells(grid, grid[1][1])
+	for _, cell := range neighbours {
+		if cell.Col == 2 {
+			t.Fatalf("neighbour %v is over the wall", cell.Index())
+		}
+	}
+
+	// Up, across and down again, straight all the way as cutting the corner
+	// would brush the wall
+	if d := grid.DistanceField(Point{1, 0})[0][2]; d != 50 {
+		t.Fatalf("distance across the wall %d, want 50", d)
+	}
+
+	steps, err := FindPathFewestSteps(grid, Point{1, 0}, Point{2, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 6 {
+		t.Fatalf("fewest steps path %v, want 6 cells round the wall", PathPoints(steps))
+	}
+
+	if LineOfSight(grid, Point{0, 0}, Point{3, 0}) {
+		t.Fatal("line of sight through the wall")
+	}
+}
+
+func TestAddEdgeWallResetsComponents(t *testing.T) {
+	s := NewSolver(NewGrid(3, 1), WithComponents())
+
+	if _, err := s.FindPath(Point{0, 0}, Point{2, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.AddEdgeWall(Point{1, 0}, Point{2, 0})
+
+	// Regions worked out again, so the search doesn't even start
+	_, err := s.FindPath(Point{0, 0}, Point{2, 0})
+	var noPath *NoPathError
+	if !errors.As(err, &noPath) || s.Stats.Expansions != 0 {
+		t.Fatalf("got %v after %d expansions, want no path without expanding", err, s.Stats.Expansions)
+	}
+}
+
+func TestAddEdgeWallResetsClearance(t *testing.T) {
+	s := NewSolver(NewGrid(7, 7), WithAgentRadius(1))
+	start, target := Point{1, 3}, Point{5, 3}
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsPoint(path, Point{3, 3}) {
+		t.Fatalf("path %v, want it straight along row 3", PathPoints(path))
+	}
+
+	// Cells beside the new wall are too tight for the agent now
+	s.AddEdgeWall(Point{3, 3}, Point{3, 4})
+
+	path, err = s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsPoint(path, Point{3, 3}) {
+		t.Fatalf("path %v goes beside the edge wall", PathPoints(path))
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+// ErodeWalkable - copy of the grid with every walkable cell within radius steps
+// of a wall turned into a wall too, counting diagonal steps as one, for planning
+// that keeps well clear of walls. The edge of the grid doesn't count as a wall.
+func (g Grid) ErodeWalkable(radius int) Grid {
+	eroded := g.Clone()
+
+	for y := range g {
+		for x := range g[y] {
+			if g[y][x].State != DISABLED {
+				continue
+			}
+
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					if eroded.InBounds(x+dx, y+dy) {
+						eroded[y+dy][x+dx].State = DISABLED
+					}
+				}
+			}
+		}
+	}
+
+	return eroded
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestErodeWalkableCorridors(t *testing.T) {
+	narrow := parseGrid(
+		"#######",
+		".......",
+		"#######",
+	)
+	if _, err := FindPath(narrow.ErodeWalkable(1), Point{0, 1}, Point{6, 1}); err == nil {
+		t.Fatal("1-wide corridor still open after eroding by 1")
+	}
+
+	wide := parseGrid(
+		"#######",
+		".......",
+		".......",
+		".......",
+		"#######",
+	)
+	eroded := wide.ErodeWalkable(1)
+
+	if _, err := FindPath(eroded, Point{0, 2}, Point{6, 2}); err != nil {
+		t.Fatalf("3-wide corridor closed after eroding by 1: %v", err)
+	}
+	if eroded[1][3].State != DISABLED || wide[1][3].State == DISABLED {
+		t.Fatal("erosion should wall off the copy's cells next to walls and leave the grid alone")
+	}
+}
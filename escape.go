@@ -0,0 +1,65 @@
+package main
+
+import "math"
+
+// EscapeRoute - path of at most budget moves from start to the cell furthest
+// from threat, measured by the cost of the cheapest path from threat. Ties go to
+// the cell fewer moves away. Cells threat can't reach count as furthest of all.
+func EscapeRoute(grid Grid, start Point, threat Point, budget int) ([]*Cell, error) {
+	if !grid.InBounds(start.X, start.Y) {
+		return nil, ErrOutOfBounds
+	}
+
+	startCell := grid[start.Y][start.X]
+	if !startCell.Walkable() {
+		return nil, &NoPathError{}
+	}
+
+	danger := grid.DistanceField(threat)
+	safety := func(cell *Cell) int {
+		if d := danger[cell.Row][cell.Col]; d != -1 {
+			return d
+		}
+
+		return math.MaxInt
+	}
+
+	// Breadth first, so every cell is reached in the fewest moves
+	parent := map[*Cell]*Cell{startCell: nil}
+	frontier := []*Cell{startCell}
+	best := startCell
+
+	for moves := 0; moves < budget && len(frontier) > 0; moves++ {
+		var next []*Cell
+
+		for _, cell := range frontier {
+			neighbours, _ := GetNeighbourCells(grid, cell)
+
+			for _, neighbour := range neighbours {
+				if _, seen := parent[neighbour]; seen {
+					continue
+				}
+
+				parent[neighbour] = cell
+				next = append(next, neighbour)
+
+				if safety(neighbour) > safety(best) {
+					best = neighbour
+				}
+			}
+		}
+
+		frontier = next
+	}
+
+	var path []*Cell
+	for cell := best; cell != nil; cell = parent[cell] {
+		path = append(path, cell)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestEscapeRouteMovesAway(t *testing.T) {
+	grid := NewGrid(10, 10)
+	threat, start := Point{2, 2}, Point{3, 3}
+	budget := 4
+
+	path, err := EscapeRoute(grid, start, threat, budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	if len(path)-1 > budget {
+		t.Fatalf("path %v takes %d moves, budget is %d", PathPoints(path), len(path)-1, budget)
+	}
+	if len(path)-1 != budget {
+		t.Fatalf("path %v stops short in an open room", PathPoints(path))
+	}
+
+	danger := grid.DistanceField(threat)
+	for i := 1; i < len(path); i++ {
+		if danger[path[i].Row][path[i].Col] <= danger[path[i-1].Row][path[i-1].Col] {
+			t.Fatalf("path %v doesn't move away from the threat at step %d", PathPoints(path), i)
+		}
+	}
+}
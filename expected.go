@@ -0,0 +1,258 @@
+package main
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// ErrScenarioWeights - returned when scenarios and weights don't line up
+var ErrScenarioWeights = errors.New("need one weight of 0 or more per scenario, adding up to more than 0")
+
+// ErrTooManyScenarios - returned when FindPathExpected is given more than 64
+// scenarios
+var ErrTooManyScenarios = errors.New("at most 64 scenarios")
+
+// WallSet - extra walls, by grid index
+type WallSet map[Point]bool
+
+// ExpectedPath - path planned by FindPathExpected
+type ExpectedPath struct {
+	Path []*Cell
+
+	// Cost - expected move cost of setting off along Path, weighted over the
+	// scenarios in which the target can be reached. In a scenario with a wall on
+	// Path the mover finds the wall when it tries to step in, and from there takes
+	// that scenario's cheapest way to the target.
+	Cost float64
+
+	// Unreachable - indices of the scenarios in which the target can't be reached
+	// from start at all, left out of Cost
+	Unreachable []int
+}
+
+// FindPathExpected - path from start to target with the lowest expected cost
+// over scenarios, each one a set of extra walls that turns up with the given
+// weight. The mover doesn't know which scenario it's in until it runs into one
+// of its walls, see ExpectedPath.Cost, so the path never enters a cell that is a
+// wall in every scenario still possible by then. Counts move costs only, no
+// Weight. The grid itself is left untouched.
+func FindPathExpected(grid Grid, start Point, target Point, scenarios []WallSet, weights []float64) (ExpectedPath, error) {
+	var result ExpectedPath
+
+	if len(scenarios) != len(weights) {
+		return result, ErrScenarioWeights
+	}
+
+	if len(scenarios) > 64 {
+		return result, ErrTooManyScenarios
+	}
+
+	if !grid.InBounds(start.X, start.Y) || !grid.InBounds(target.X, target.Y) {
+		return result, ErrOutOfBounds
+	}
+
+	if len(scenarios) == 0 {
+		// Nothing uncertain, one scenario without extra walls
+		scenarios, weights = []WallSet{nil}, []float64{1}
+	}
+
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return result, ErrScenarioWeights
+		}
+
+		total += weight
+	}
+
+	if total <= 0 {
+		return result, ErrScenarioWeights
+	}
+
+	// remaining[i] - cheapest cost from every cell to the target in scenario i
+	remaining := make([][][]int, len(scenarios))
+	reachable := 0.0
+	var alive uint64
+
+	for i, walls := range scenarios {
+		remaining[i] = scenarioCostField(grid, walls, target)
+
+		if distanceAt(remaining[i], start) == -1 {
+			result.Unreachable = append(result.Unreachable, i)
+			continue
+		}
+
+		alive |= 1 << i
+		reachable += weights[i]
+	}
+
+	if reachable <= 0 {
+		return result, &NoPathError{}
+	}
+
+	shares := make([]float64, len(weights))
+	for i := range weights {
+		shares[i] = weights[i] / reachable
+	}
+
+	s := NewSolver(grid)
+	e := &expectedSearch{s: s, scenarios: scenarios, shares: shares, remaining: remaining}
+
+	path, cost, err := e.findPath(s.Grid[start.Y][start.X], s.Grid[target.Y][target.X], alive)
+	if err != nil {
+		return result, err
+	}
+
+	result.Path, result.Cost = path, cost
+
+	return result, nil
+}
+
+// scenarioCostField - cost from every cell to target with walls added, -1 where
+// it can't be reached
+func scenarioCostField(grid Grid, walls WallSet, target Point) [][]int {
+	clone := grid.Clone()
+
+	for p, wall := range walls {
+		if wall && clone.InBounds(p.X, p.Y) {
+			clone[p.Y][p.X].State = DISABLED
+		}
+	}
+
+	return NewSolver(clone).moveCostField(target, true, false)
+}
+
+// scenarioState - a cell and the scenarios, one bit each, whose walls haven't
+// been run into on the way there
+type scenarioState struct {
+	cell  *Cell
+	alive uint64
+}
+
+// expectedSearch - A* over scenarioStates, G being the expected cost so far
+type expectedSearch struct {
+	s         *Solver
+	scenarios []WallSet
+	shares    []float64
+	remaining [][][]int
+}
+
+// estimate - expected cost left if every scenario still possible could take its
+// own cheapest way, a lower bound
+func (e *expectedSearch) estimate(st scenarioState) float64 {
+	h := 0.0
+
+	for i := range e.scenarios {
+		if st.alive&(1<<i) != 0 {
+			h += e.shares[i] * float64(e.remaining[i][st.cell.Row][st.cell.Col])
+		}
+	}
+
+	return h
+}
+
+// move - state after trying to step from cur into next and expected cost of
+// the attempt. Scenarios with a wall in next end there, finishing along their
+// cheapest way from cur. False when next is a wall in all of them.
+func (e *expectedSearch) move(cur scenarioState, next *Cell, step int) (scenarioState, float64, bool) {
+	moved := scenarioState{next, cur.alive}
+	cost := 0.0
+
+	for i := range e.scenarios {
+		if cur.alive&(1<<i) == 0 {
+			continue
+		}
+
+		if !e.scenarios[i][next.Index()] {
+			cost += e.shares[i] * float64(step)
+			continue
+		}
+
+		rest := e.remaining[i][cur.cell.Row][cur.cell.Col]
+		if rest == -1 {
+			return moved, 0, false
+		}
+
+		moved.alive &^= 1 << i
+		cost += e.shares[i] * float64(rest)
+	}
+
+	return moved, cost, moved.alive != 0
+}
+
+func (e *expectedSearch) findPath(startCell *Cell, targetCell *Cell, alive uint64) ([]*Cell, float64, error) {
+	first := scenarioState{startCell, alive}
+	g := map[scenarioState]float64{first: 0}
+	parent := make(map[scenarioState]scenarioState)
+	closed := make(map[scenarioState]bool)
+	queue := &scenarioQueue{{first, 0, e.estimate(first)}}
+	expansions := 0
+
+	var neighbourBuf [8]*Cell
+	var costBuf [8]int
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(scenarioItem)
+		cur := item.state
+
+		if closed[cur] {
+			// Stale entry, the state was reached cheaper since
+			continue
+		}
+
+		closed[cur] = true
+		expansions++
+
+		if cur.cell == targetCell {
+			path := []*Cell{cur.cell}
+			for st := cur; st != first; {
+				st = parent[st]
+				path = append(path, st.cell)
+			}
+
+			return reversePath(path), item.g, nil
+		}
+
+		neighbours, costs := e.s.neighbours(cur.cell, neighbourBuf[:0], costBuf[:0])
+
+		for n, neighbour := range neighbours {
+			next, cost, ok := e.move(cur, neighbour, e.s.stepCost(cur.cell, neighbour, costs[n]))
+			if !ok || closed[next] {
+				continue
+			}
+
+			newG := item.g + cost
+			if old, seen := g[next]; seen && old <= newG {
+				continue
+			}
+
+			g[next] = newG
+			parent[next] = cur
+			heap.Push(queue, scenarioItem{next, newG, newG + e.estimate(next)})
+		}
+	}
+
+	return nil, 0, &NoPathError{Expansions: expansions}
+}
+
+type scenarioItem struct {
+	state    scenarioState
+	g        float64
+	priority float64
+}
+
+// scenarioQueue - min-heap of scenario states by priority, for container/heap
+type scenarioQueue []scenarioItem
+
+func (q scenarioQueue) Len() int            { return len(q) }
+func (q scenarioQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q scenarioQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *scenarioQueue) Push(x interface{}) { *q = append(*q, x.(scenarioItem)) }
+
+func (q *scenarioQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
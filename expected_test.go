@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// corridors - four corridors from the left column to the right one, rows 0, 2,
+// 4 and 6, with the walls of two scenarios near their right ends
+func corridors() (Grid, []WallSet) {
+	grid := parseGrid(
+		".........",
+		".#######.",
+		".........",
+		".#######.",
+		".........",
+		".#######.",
+		".........",
+	)
+
+	// Each leaves only one of the top and bottom corridors open, neither touches
+	// the far one
+	scenarios := []WallSet{
+		{{7, 2}: true, {7, 4}: true},
+		{{7, 2}: true, {7, 0}: true},
+	}
+
+	return grid, scenarios
+}
+
+func TestFindPathExpectedRobust(t *testing.T) {
+	grid, scenarios := corridors()
+	start, target := Point{0, 2}, Point{8, 2}
+
+	result, err := FindPathExpected(grid, start, target, scenarios, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, result.Path)
+
+	for i, walls := range scenarios {
+		s := NewSolver(grid)
+		s.NeighbourFilter = func(from *Cell, to *Cell) bool { return !walls[to.Index()] }
+
+		own, err := s.FindPath(start, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reflect.DeepEqual(PathPoints(own), PathPoints(result.Path)) {
+			t.Fatalf("robust path %v is scenario %d's own best path", PathPoints(result.Path), i)
+		}
+	}
+
+	// The far corridor is open in both, so the expected cost is its plain cost
+	if !containsPoint(result.Path, Point{4, 6}) {
+		t.Fatalf("robust path %v doesn't take the far corridor", PathPoints(result.Path))
+	}
+	if want := float64(moveCost(result.Path)); math.Abs(result.Cost-want) > 1e-9 {
+		t.Fatalf("expected cost %v, want %v", result.Cost, want)
+	}
+	if len(result.Unreachable) != 0 {
+		t.Fatalf("scenarios %v reported unreachable", result.Unreachable)
+	}
+}
+
+func TestFindPathExpectedLikelyScenario(t *testing.T) {
+	grid, scenarios := corridors()
+
+	// Almost certainly the first scenario: worth risking its open top corridor
+	result, err := FindPathExpected(grid, Point{0, 2}, Point{8, 2}, scenarios, []float64{99, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !containsPoint(result.Path, Point{4, 0}) {
+		t.Fatalf("path %v doesn't take the top corridor", PathPoints(result.Path))
+	}
+
+	// One move in a hundred times the way back from the wall and round
+	if cost := float64(moveCost(result.Path)); result.Cost <= cost {
+		t.Fatalf("expected cost %v isn't above the path's own %v", result.Cost, cost)
+	}
+}
+
+func TestFindPathExpectedUnreachable(t *testing.T) {
+	grid, scenarios := corridors()
+	target := Point{8, 2}
+
+	// A third scenario walls the target in
+	scenarios = append(scenarios, WallSet{{8, 1}: true, {8, 3}: true, {7, 2}: true})
+
+	result, err := FindPathExpected(grid, Point{0, 2}, target, scenarios, []float64{1, 1, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Unreachable, []int{2}) {
+		t.Fatalf("unreachable scenarios %v, want [2]", result.Unreachable)
+	}
+	if !containsPoint(result.Path, Point{4, 6}) {
+		t.Fatalf("path %v should be planned over the two reachable scenarios alone", PathPoints(result.Path))
+	}
+
+	_, err = FindPathExpected(grid, Point{0, 2}, target, scenarios[2:], []float64{1})
+	var noPath *NoPathError
+	if !errors.As(err, &noPath) {
+		t.Fatalf("got %v with only the unreachable scenario, want NoPathError", err)
+	}
+
+	if _, err := FindPathExpected(grid, Point{0, 2}, target, scenarios, []float64{1}); !errors.Is(err, ErrScenarioWeights) {
+		t.Fatalf("got %v for mismatched weights, want ErrScenarioWeights", err)
+	}
+}
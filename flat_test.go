@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// scatterWalls - walls on about one cell in five of a width x height map, the
+// same ones on every call, with the corners left open
+func scatterWalls(width int, height int, wall func(x int, y int)) {
+	rng := rand.New(rand.NewSource(5))
+
+	for i := 0; i < width*height/5; i++ {
+		x, y := rng.Intn(width), rng.Intn(height)
+		if (x != 0 || y != 0) && (x != width-1 || y != height-1) {
+			wall(x, y)
+		}
+	}
+}
+
+func TestFlatGridSamePath(t *testing.T) {
+	flat := NewFlatGrid(30, 20)
+	grid := NewGrid(30, 20)
+
+	scatterWalls(30, 20, func(x int, y int) {
+		flat.At(x, y).State = DISABLED
+		grid[y][x].State = DISABLED
+	})
+
+	want, wantErr := FindPath(grid, Point{0, 0}, Point{29, 19})
+	path, err := flat.FindPath(Point{0, 0}, Point{29, 19})
+
+	if (err == nil) != (wantErr == nil) || !reflect.DeepEqual(PathPoints(path), PathPoints(want)) {
+		t.Fatalf("flat grid path %v (%v), pointer grid %v (%v)", PathPoints(path), err, PathPoints(want), wantErr)
+	}
+
+	// Both views share the cells
+	if flat.Grid()[3][4] != flat.At(4, 3) {
+		t.Fatal("Grid view doesn't point into Cells")
+	}
+}
+
+const benchmarkMapSize = 300
+
+func BenchmarkFindPathPointerGrid(b *testing.B) {
+	grid := NewGrid(benchmarkMapSize, benchmarkMapSize)
+	scatterWalls(benchmarkMapSize, benchmarkMapSize, func(x int, y int) { grid[y][x].State = DISABLED })
+
+	s := NewSolver(grid)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.FindPath(Point{0, 0}, Point{benchmarkMapSize - 1, benchmarkMapSize - 1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Same search as BenchmarkFindPathPointerGrid through the Grid view, expected to
+// run at about the same speed
+func BenchmarkFindPathFlatGrid(b *testing.B) {
+	flat := NewFlatGrid(benchmarkMapSize, benchmarkMapSize)
+	scatterWalls(benchmarkMapSize, benchmarkMapSize, func(x int, y int) { flat.At(x, y).State = DISABLED })
+
+	s := NewSolver(flat.Grid())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.FindPath(Point{0, 0}, Point{benchmarkMapSize - 1, benchmarkMapSize - 1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewGrid(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewGrid(benchmarkMapSize, benchmarkMapSize)
+	}
+}
+
+// Where FlatGrid pays off, against BenchmarkNewGrid
+func BenchmarkNewFlatGrid(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewFlatGrid(benchmarkMapSize, benchmarkMapSize)
+	}
+}
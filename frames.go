@@ -0,0 +1,35 @@
+package main
+
+// DefaultMaxFrames - frames kept by RecordFrames unless Solver.MaxFrames says otherwise
+const DefaultMaxFrames = 1000
+
+// RecordFrames - the grid drawn before the search and again after every
+// expansion, using the default settings
+func RecordFrames(grid Grid, start Point, target Point) []string {
+	return NewSolver(grid).RecordFrames(start, target)
+}
+
+// RecordFrames - the grid drawn before the search and again after every
+// expansion, at most MaxFrames of them (0 = no limit). Frames past the limit
+// are dropped, the search still runs to the end.
+func (s *Solver) RecordFrames(start Point, target Point) []string {
+	st := s.NewStepper(start, target)
+
+	frames := []string{renderGrid(start, target, s.Grid, st.nodes)}
+
+	for !st.Done() {
+		expansions := s.Stats.Expansions
+		st.Step()
+
+		if s.Stats.Expansions == expansions {
+			// Nothing closed, e.g. a LazyHeuristic re-push or the end of the search
+			continue
+		}
+
+		if s.MaxFrames == 0 || len(frames) < s.MaxFrames {
+			frames = append(frames, renderGrid(start, target, s.Grid, st.nodes))
+		}
+	}
+
+	return frames
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecordFramesOnePerExpansion(t *testing.T) {
+	grid := parseGrid(
+		"......",
+		"..#...",
+		"..#...",
+		"......",
+	)
+
+	s := NewSolver(grid)
+	frames := s.RecordFrames(Point{0, 2}, Point{5, 1})
+
+	if want := s.Stats.Expansions + 1; len(frames) != want {
+		t.Fatalf("got %d frames for %d expansions, want %d", len(frames), s.Stats.Expansions, want)
+	}
+
+	if strings.Contains(frames[0], "[-]") {
+		t.Fatal("first frame shows expanded cells before the search started")
+	}
+	if !strings.Contains(frames[len(frames)-1], "[-]") {
+		t.Fatal("last frame shows no expanded cells")
+	}
+}
+
+func TestRecordFramesLazyHeuristic(t *testing.T) {
+	grid := parseGrid(
+		"......",
+		"..#...",
+		"..#...",
+		"......",
+	)
+
+	// Re-pushed cells go back in line without being closed, so draw no frame
+	s := NewSolver(grid)
+	s.LazyHeuristic = true
+	frames := s.RecordFrames(Point{0, 2}, Point{5, 1})
+
+	if want := s.Stats.Expansions + 1; len(frames) != want {
+		t.Fatalf("got %d frames for %d expansions, want %d", len(frames), s.Stats.Expansions, want)
+	}
+}
+
+func TestRecordFramesLimit(t *testing.T) {
+	s := NewSolver(NewGrid(10, 10))
+	s.MaxFrames = 3
+
+	frames := s.RecordFrames(Point{0, 0}, Point{9, 9})
+
+	if len(frames) != 3 {
+		t.Fatalf("got %d frames, want MaxFrames 3", len(frames))
+	}
+	if s.Stats.Expansions <= 3 {
+		t.Fatalf("search stopped after %d expansions", s.Stats.Expansions)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// FPoint - fractional world position, cell centres sit on whole numbers
+type FPoint struct {
+	X float64
+	Y float64
+}
+
+// InterpolatePath - positions along path, stepsPerCell of them per move between
+// two cells (diagonal moves included) plus the last cell, for smooth movement
+// between cell centres
+func InterpolatePath(path []*Cell, stepsPerCell int) []FPoint {
+	if len(path) == 0 {
+		return nil
+	}
+
+	if stepsPerCell < 1 {
+		stepsPerCell = 1
+	}
+
+	points := make([]FPoint, 0, (len(path)-1)*stepsPerCell+1)
+
+	for i := 0; i+1 < len(path); i++ {
+		from, to := path[i], path[i+1]
+		dx := float64(to.X - from.X)
+		dy := float64(to.Y - from.Y)
+
+		for step := 0; step < stepsPerCell; step++ {
+			t := float64(step) / float64(stepsPerCell)
+			points = append(points, FPoint{float64(from.X) + dx*t, float64(from.Y) + dy*t})
+		}
+	}
+
+	last := path[len(path)-1]
+	points = append(points, FPoint{float64(last.X), float64(last.Y)})
+
+	return points
+}
+
+// SnapPathToGrid - cells containing each of pts, the inverse of InterpolatePath.
+// A point on the line between the last cell and a neighbour, as InterpolatePath
+// gives for a move, goes to whichever of the two is nearer, so halfway along a
+// diagonal move stays on the move instead of rounding onto a corner cell. Points
+// in the same cell one after the other give that cell once. Fails when a point
+// is off the grid or on a wall.
+func SnapPathToGrid(grid Grid, pts []FPoint) ([]*Cell, error) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return nil, ErrOutOfBounds
+	}
+
+	// World position of the first cell, the rest follow on from it
+	origin := grid[0][0]
+
+	var path []*Cell
+
+	for _, p := range pts {
+		x, y := int(math.Round(p.X)), int(math.Round(p.Y))
+
+		if len(path) > 0 {
+			x, y = snapToMove(path[len(path)-1], p, x, y)
+		}
+
+		x, y = x-origin.X, y-origin.Y
+
+		if !grid.InBounds(x, y) {
+			return nil, ErrOutOfBounds
+		}
+
+		cell := grid[y][x]
+		if !cell.Walkable() {
+			return nil, fmt.Errorf("point (%v, %v) is on a wall", p.X, p.Y)
+		}
+
+		if len(path) == 0 || path[len(path)-1] != cell {
+			path = append(path, cell)
+		}
+	}
+
+	return path, nil
+}
+
+// snapToMove - world position p snaps to after from: from or the neighbour
+// towards p when p is on the line between their centres, x, y otherwise
+func snapToMove(from *Cell, p FPoint, x int, y int) (int, int) {
+	const epsilon = 1e-9
+
+	dx, dy := p.X-float64(from.X), p.Y-float64(from.Y)
+	if math.Abs(dx) > 1+epsilon || math.Abs(dy) > 1+epsilon {
+		return x, y
+	}
+
+	stepX, stepY := moveStep(dx, epsilon), moveStep(dy, epsilon)
+
+	// Off the line between the centres
+	if math.Abs(dx*float64(stepY)-dy*float64(stepX)) > epsilon {
+		return x, y
+	}
+
+	// Along the move, nearer the start
+	if math.Abs(dx)+math.Abs(dy) <= float64(abs(stepX)+abs(stepY))/2+epsilon {
+		return from.X, from.Y
+	}
+
+	return from.X + stepX, from.Y + stepY
+}
+
+// moveStep - -1, 0 or 1 for the direction of an offset, offsets within epsilon
+// of 0 count as 0
+func moveStep(d float64, epsilon float64) int {
+	switch {
+	case d > epsilon:
+		return 1
+	case d < -epsilon:
+		return -1
+	}
+
+	return 0
+}
+
+// PolarStep - a move as a heading in degrees, 0 along +X (EAST) and 90 along
+// +Y (NORTH), and a distance in cells
+type PolarStep struct {
+	Angle    float64
+	Distance float64
+}
+
+// PathPolar - moves between consecutive cells of path as headings and
+// distances, for agents steered by heading and thrust. Angles run from 0 up to
+// but not including 360, diagonal moves are multiples of 45 degrees long √2.
+func PathPolar(path []*Cell) []PolarStep {
+	if len(path) < 2 {
+		return nil
+	}
+
+	steps := make([]PolarStep, len(path)-1)
+
+	for i := range steps {
+		dx := float64(path[i+1].X - path[i].X)
+		dy := float64(path[i+1].Y - path[i].Y)
+
+		angle := math.Atan2(dy, dx) * 180 / math.Pi
+		if angle < 0 {
+			angle += 360
+		}
+
+		steps[i] = PolarStep{Angle: angle, Distance: math.Hypot(dx, dy)}
+	}
+
+	return steps
+}
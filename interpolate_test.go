@@ -0,0 +1,74 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestInterpolatePathTwoCells(t *testing.T) {
+	grid := NewGrid(2, 2)
+
+	points := InterpolatePath([]*Cell{grid[0][0], grid[0][1]}, 4)
+	want := []FPoint{{0, 0}, {0.25, 0}, {0.5, 0}, {0.75, 0}, {1, 0}}
+
+	if !reflect.DeepEqual(points, want) {
+		t.Fatalf("got %v, want %v", points, want)
+	}
+
+	diagonal := InterpolatePath([]*Cell{grid[0][0], grid[1][1]}, 2)
+	if want := []FPoint{{0, 0}, {0.5, 0.5}, {1, 1}}; !reflect.DeepEqual(diagonal, want) {
+		t.Fatalf("diagonal gave %v, want %v", diagonal, want)
+	}
+}
+
+func TestSnapPathToGridRoundTrip(t *testing.T) {
+	// Halfway along each diagonal move is the corner of the two walls, which
+	// rounding alone would snap onto
+	grid := parseGrid(
+		"#.#",
+		".#.",
+	)
+	path := []*Cell{grid[1][0], grid[0][1], grid[1][2]}
+
+	for _, steps := range []int{1, 2, 4, 5} {
+		snapped, err := SnapPathToGrid(grid, InterpolatePath(path, steps))
+		if err != nil {
+			t.Fatalf("%d steps per cell: %v", steps, err)
+		}
+		if !reflect.DeepEqual(PathPoints(snapped), PathPoints(path)) {
+			t.Fatalf("%d steps per cell gave %v, want %v", steps, PathPoints(snapped), PathPoints(path))
+		}
+	}
+
+	if _, err := SnapPathToGrid(grid, []FPoint{{0, 0}}); err == nil {
+		t.Fatal("snapped onto a wall")
+	}
+}
+
+func TestPathPolar(t *testing.T) {
+	grid := NewGrid(3, 4)
+	path := []*Cell{grid[0][0], grid[0][1], grid[1][2], grid[2][2], grid[3][1], grid[3][0], grid[2][0], grid[1][1]}
+
+	steps := PathPolar(path)
+	wantAngles := []float64{0, 45, 90, 135, 180, 270, 315}
+
+	if len(steps) != len(wantAngles) {
+		t.Fatalf("got %d steps %v, want %d", len(steps), steps, len(wantAngles))
+	}
+
+	for i, step := range steps {
+		wantDistance := 1.0
+		if int(wantAngles[i])%90 != 0 {
+			wantDistance = math.Sqrt2
+		}
+
+		if math.Abs(step.Angle-wantAngles[i]) > 1e-9 || math.Abs(step.Distance-wantDistance) > 1e-9 {
+			t.Fatalf("step %d is %v, want angle %v distance %v", i, step, wantAngles[i], wantDistance)
+		}
+	}
+
+	if PathPolar(path[:1]) != nil {
+		t.Fatal("one cell path has steps")
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"container/heap"
+)
+
+// Landmarks - exact distances from and to a few landmark cells, for the ALT
+// heuristic
+type Landmarks struct {
+	Points []Point
+
+	// from[i], to[i] - cost from Points[i] to every cell and from every cell to
+	// Points[i], [row][column]. The same tables unless moves are one way.
+	from [][][]int
+	to   [][][]int
+}
+
+// PrecomputeLandmarks - picks up to count landmarks spread across the walkable
+// cells (each one as far as possible from the ones before) and stores their
+// distance fields under the default move costs. WithLandmarkHeuristic measures
+// them again under the solver's own rules. Only valid for as long as the walls
+// don't change.
+func PrecomputeLandmarks(grid Grid, count int) *Landmarks {
+	lm := &Landmarks{}
+
+	var first *Cell
+	for y := range grid {
+		for x := range grid[y] {
+			if first == nil && grid[y][x].State != DISABLED {
+				first = grid[y][x]
+			}
+		}
+	}
+
+	if first == nil || count < 1 {
+		return lm
+	}
+
+	next := first.Index()
+
+	for len(lm.Points) < count {
+		dist := grid.DistanceField(next)
+
+		lm.Points = append(lm.Points, next)
+		lm.from = append(lm.from, dist)
+		lm.to = append(lm.to, dist)
+
+		// Next landmark is the reachable cell furthest from all the current ones
+		best := -1
+
+		for y := range grid {
+			for x := range grid[y] {
+				nearest := -1
+
+				for _, dist := range lm.from {
+					if dist[y][x] != -1 && (nearest == -1 || dist[y][x] < nearest) {
+						nearest = dist[y][x]
+					}
+				}
+
+				if nearest > best {
+					best = nearest
+					next = Point{x, y}
+				}
+			}
+		}
+
+		if best <= 0 {
+			// Every reachable cell already is a landmark
+			break
+		}
+	}
+
+	return lm
+}
+
+// Heuristic - lower bound from the triangle inequality, the biggest of
+// d(L, to) - d(L, from) and d(from, L) - d(to, L) over the landmarks L that
+// reach both points
+func (lm *Landmarks) Heuristic(from Point, to Point) int {
+	best := 0
+
+	for i := range lm.Points {
+		if dFrom, dTo := distanceAt(lm.from[i], from), distanceAt(lm.from[i], to); dFrom != -1 && dTo != -1 {
+			best = max(best, dTo-dFrom)
+		}
+
+		if dFrom, dTo := distanceAt(lm.to[i], from), distanceAt(lm.to[i], to); dFrom != -1 && dTo != -1 {
+			best = max(best, dFrom-dTo)
+		}
+	}
+
+	return best
+}
+
+// distanceAt - entry of a distance field at p, -1 off the field
+func distanceAt(dist [][]int, p Point) int {
+	if p.Y < 0 || p.Y >= len(dist) || p.X < 0 || p.X >= len(dist[p.Y]) {
+		return -1
+	}
+
+	return dist[p.Y][p.X]
+}
+
+// WithLandmarkHeuristic - search with the ALT heuristic of lm. NewSolver
+// measures the landmarks again with the solver's movement, move costs, cell
+// size and MaxClimb, so the estimate stays a lower bound under them, and lm
+// itself is left as it is.
+func WithLandmarkHeuristic(lm *Landmarks) Option {
+	return func(s *Solver) {
+		s.landmarks = lm
+		s.Heuristic = nil
+	}
+}
+
+// measure - lm with its distances worked out under the rules of s
+func (lm *Landmarks) measure(s *Solver) *Landmarks {
+	measured := &Landmarks{Points: lm.Points}
+
+	for _, p := range lm.Points {
+		from := s.moveCostField(p, false, false)
+
+		to := from
+		if s.MaxClimb >= 0 {
+			// Climbing makes some moves one way
+			to = s.moveCostField(p, true, false)
+		}
+
+		measured.from = append(measured.from, from)
+		measured.to = append(measured.to, to)
+	}
+
+	return measured
+}
+
+// moveCostField - cost of the cheapest way from root to every cell [row][column]
+// under the movement rules of s, or from every cell to root when reverse, -1
+// where there is none. Counts the move costs scaled by the cell size, plus the
+// Weight of every cell entered when weighted, not the other extras.
+func (s *Solver) moveCostField(root Point, reverse bool, weighted bool) [][]int {
+	dist := make([][]int, len(s.Grid))
+	for y := range s.Grid {
+		dist[y] = make([]int, len(s.Grid[y]))
+
+		for x := range dist[y] {
+			dist[y][x] = -1
+		}
+	}
+
+	if !s.Grid.InBounds(root.X, root.Y) || s.Grid[root.Y][root.X].State == DISABLED {
+		return dist
+	}
+
+	dist[root.Y][root.X] = 0
+	queue := &distanceQueue{{cell: s.Grid[root.Y][root.X]}}
+
+	var neighbourBuf [8]*Cell
+	var costBuf [8]int
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(distanceItem)
+		if item.dist != dist[item.cell.Row][item.cell.Col] {
+			// Stale entry, the cell was reached cheaper since
+			continue
+		}
+
+		neighbours, costs := s.neighbours(item.cell, neighbourBuf[:0], costBuf[:0])
+
+		for n, neighbour := range neighbours {
+			from, to := item.cell, neighbour
+			if reverse {
+				from, to = neighbour, item.cell
+			}
+
+			if !s.canClimb(from, to) {
+				continue
+			}
+
+			newDist := item.dist + s.stepCost(from, to, costs[n])
+			if weighted {
+				newDist += to.Weight
+			}
+			if old := dist[neighbour.Row][neighbour.Col]; old == -1 || newDist < old {
+				dist[neighbour.Row][neighbour.Col] = newDist
+				heap.Push(queue, distanceItem{cell: neighbour, dist: newDist})
+			}
+		}
+	}
+
+	return dist
+}
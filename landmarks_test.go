@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+)
+
+// maze - 21x21 grid of long walls with gaps at alternating ends
+func maze() Grid {
+	grid := NewGrid(21, 21)
+
+	for x := 2; x < 21; x += 4 {
+		for y := 0; y < 20; y++ {
+			grid[y][x].State = DISABLED
+		}
+	}
+	for x := 4; x < 21; x += 4 {
+		for y := 1; y < 21; y++ {
+			grid[y][x].State = DISABLED
+		}
+	}
+
+	return grid
+}
+
+func TestLandmarkHeuristicExpandsLess(t *testing.T) {
+	grid := maze()
+	start, target := Point{0, 20}, Point{20, 0}
+
+	manhattan := NewSolver(grid, WithHeuristic(Manhattan))
+	if _, err := manhattan.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+
+	alt := NewSolver(grid, WithLandmarkHeuristic(PrecomputeLandmarks(grid, 4)))
+	if _, err := alt.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := exactCost(NewSolver(grid), start, target); alt.Stats.Cost != want {
+		t.Fatalf("ALT path costs %d, want %d", alt.Stats.Cost, want)
+	}
+
+	if alt.Stats.Expansions >= manhattan.Stats.Expansions {
+		t.Fatalf("ALT expanded %d cells, Manhattan %d", alt.Stats.Expansions, manhattan.Stats.Expansions)
+	}
+}
+
+// exactCost - cost of the cheapest path under the settings of s, by a search
+// with no heuristic
+func exactCost(s *Solver, start Point, target Point) int {
+	s.Heuristic = func(from Point, to Point) int { return 0 }
+	s.landmarks = nil
+
+	if _, err := s.FindPath(start, target); err != nil {
+		return -1
+	}
+
+	return s.Stats.Cost
+}
+
+func TestLandmarkHeuristicFollowsSolverRules(t *testing.T) {
+	grid := NewGrid(8, 8)
+	for y := range grid {
+		for x := range grid[y] {
+			// Each column a step higher, so moves east are one way
+			grid[y][x].Elevation = x
+		}
+	}
+	lm := PrecomputeLandmarks(grid, 3)
+
+	noClimbing := func(s *Solver) { s.MaxClimb = 0 }
+	s := NewSolver(grid, WithCosts(7, 9), WithMovement(CARDINAL), noClimbing, WithLandmarkHeuristic(lm))
+
+	if len(s.Warnings) != 0 {
+		t.Fatalf("warnings %v", s.Warnings)
+	}
+
+	for _, target := range []Point{{0, 7}, {0, 0}, {3, 5}} {
+		for y := range grid {
+			for x := range grid[y] {
+				exact := NewSolver(grid, WithCosts(7, 9), WithMovement(CARDINAL), noClimbing)
+
+				if h, cost := s.heuristic(Point{x, y}, target), exactCost(exact, Point{x, y}, target); cost != -1 && h > cost {
+					t.Fatalf("estimate %d from %d, %d to %v is above the cost %d", h, x, y, target, cost)
+				}
+			}
+		}
+	}
+}
+
+func TestLandmarkHeuristicReplacedByLaterOption(t *testing.T) {
+	grid := NewGrid(5, 5)
+	lm := PrecomputeLandmarks(grid, 2)
+
+	s := NewSolver(grid, WithLandmarkHeuristic(lm), WithBlendedHeuristic(1, Octile, 0, Manhattan))
+	if s.landmarks != nil || s.heuristic(Point{0, 0}, Point{4, 4}) != Octile(Point{0, 0}, Point{4, 4}) {
+		t.Fatal("landmarks still in use after a later heuristic option")
+	}
+
+	s = NewSolver(grid, WithHeuristic(Manhattan), WithLandmarkHeuristic(lm))
+	if s.landmarks == nil {
+		t.Fatal("landmarks dropped for an earlier heuristic option")
+	}
+}
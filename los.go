@@ -0,0 +1,51 @@
+package main
+
+// LineOfSight - whether every cell on the straight line between two grid points,
+// both ends included, is walkable and no edge wall stands between two of them.
+// The line is traced cell by cell like a Bresenham line, so it may slip
+// diagonally between two walls.
+func LineOfSight(grid Grid, from Point, to Point) bool {
+	var prev *Cell
+
+	for _, p := range BresenhamLine(from.X, from.Y, to.X, to.Y) {
+		if !grid.InBounds(p.X, p.Y) || !grid[p.Y][p.X].Walkable() {
+			return false
+		}
+
+		if prev != nil && edgeWall(prev, grid[p.Y][p.X]) {
+			return false
+		}
+		prev = grid[p.Y][p.X]
+	}
+
+	return true
+}
+
+// BresenhamLine - cells a straight line from x0, y0 to x1, y1 passes through,
+// both ends included, each one a neighbour of the last. Works on any integer
+// coordinates, no grid needed.
+func BresenhamLine(x0 int, y0 int, x1 int, y1 int) []Point {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	stepX, stepY := sign(x1-x0), sign(y1-y0)
+	errXY := dx + dy
+
+	points := make([]Point, 0, max(dx, -dy)+1)
+
+	for x, y := x0, y0; ; {
+		points = append(points, Point{x, y})
+
+		if x == x1 && y == y1 {
+			return points
+		}
+
+		e2 := 2 * errXY
+		if e2 >= dy {
+			errXY += dy
+			x += stepX
+		}
+		if e2 <= dx {
+			errXY += dx
+			y += stepY
+		}
+	}
+}
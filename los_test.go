@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRequireLOSFromStart(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		"..#..",
+		".....",
+	)
+	start, target := Point{0, 1}, Point{4, 1}
+
+	if LineOfSight(grid, start, target) {
+		t.Fatal("start sees through the pillar")
+	}
+
+	// The way round the pillar is fine without the gate
+	if _, err := FindPath(grid, start, target); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver(grid)
+	s.RequireLOSFromStart = true
+
+	if _, err := s.FindPath(start, target); !errors.Is(err, ErrNoPath) {
+		t.Fatalf("got %v for a target hidden behind the pillar, want ErrNoPath", err)
+	}
+
+	path, err := s.FindPath(start, Point{4, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cell := range path {
+		if !LineOfSight(grid, start, cell.Index()) {
+			t.Fatalf("path %v goes through %v, out of sight of the start", PathPoints(path), cell.Index())
+		}
+	}
+}
+
+func TestBresenhamLine(t *testing.T) {
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 int
+		want           []Point
+	}{
+		{"horizontal", 0, 0, 3, 0, []Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+		{"vertical up", 2, 3, 2, 1, []Point{{2, 3}, {2, 2}, {2, 1}}},
+		{"diagonal", 0, 0, 3, 3, []Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}}},
+		{"anti-diagonal", 2, 0, 0, 2, []Point{{2, 0}, {1, 1}, {0, 2}}},
+		{"steep", 0, 0, 1, 3, []Point{{0, 0}, {0, 1}, {1, 2}, {1, 3}}},
+		{"shallow", 0, 0, 5, 2, []Point{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}, {5, 2}}},
+		{"single point", -5, 7, -5, 7, []Point{{-5, 7}}},
+	}
+
+	for _, tt := range tests {
+		if got := BresenhamLine(tt.x0, tt.y0, tt.x1, tt.y1); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
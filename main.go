@@ -1,11 +1,38 @@
 package main
 
 import (
-	"container/list"
+	"errors"
 	"fmt"
 	"math"
+	"strings"
+	"time"
 )
 
+// ErrNoPath - returned when the target can't be reached from the start
+var ErrNoPath = errors.New("no path found")
+
+// NoPathError - ErrNoPath with the work the search did before giving up,
+// errors.Is(err, ErrNoPath) holds for it
+type NoPathError struct {
+	Expansions int
+	Elapsed    time.Duration
+}
+
+func (e *NoPathError) Error() string {
+	return fmt.Sprintf("%v after %d expansions in %v", ErrNoPath, e.Expansions, e.Elapsed)
+}
+
+func (e *NoPathError) Is(target error) bool {
+	return target == ErrNoPath
+}
+
+// ErrOutOfBounds - returned when the start or target lies outside the grid
+var ErrOutOfBounds = errors.New("point out of bounds")
+
+// ErrBudget - returned when a search used up Solver.MaxSteps without reaching
+// the target
+var ErrBudget = errors.New("search budget used up")
+
 // Grid - 2D Array of cells
 type Grid [][]*Cell
 
@@ -19,161 +46,368 @@ const (
 	PATH     = 4
 )
 
-// Cell - X, Y, H, G, state, parent
+// Point - X, Y coordinates of a cell. Points handed to the search are grid
+// indices (X = column, Y = row), which match world coordinates unless the grid
+// is a sub-grid of a bigger map.
+type Point struct {
+	X int
+	Y int
+}
+
+// Cell - world X, Y, state, capacity (0 = unlimited), grid row and column.
+// Searches keep their own bookkeeping and never write to cells, so a grid can
+// be searched from several goroutines as long as nobody edits it meanwhile.
 type Cell struct {
-	X      int
-	Y      int
-	H      int
-	G      int
-	State  CellState
-	Parent *Cell
+	X        int
+	Y        int
+	State    CellState
+	Capacity int
+	Row      int
+	Col      int
+
+	// Elevation - height of the ground, see Solver.MaxClimb
+	Elevation int
+
+	// Weight - extra cost of entering the cell
+	Weight int
+
+	// Tag - free-form type such as road or grass, NoTag for none, see PreferTag
+	Tag int
+
+	// EdgeWalls - sides of the cell with a thin wall on them, see AddEdgeWall
+	EdgeWalls Sides
+
+	// MinEnterG - lowest G the cell can be entered with, for gates that open once
+	// enough has been travelled. The search keeps one route per cell, so a gate
+	// is only reached the long way round when the cells before it are reached
+	// that way too.
+	MinEnterG int
 }
 
-func (cell *Cell) F() int {
-	return cell.G + cell.H
+// Index - position of the cell in its grid, X = column, Y = row
+func (cell *Cell) Index() Point {
+	return Point{cell.Col, cell.Row}
+}
+
+// World - position of the cell in the world
+func (cell *Cell) World() Point {
+	return Point{cell.X, cell.Y}
 }
 
 func (cell *Cell) Walkable() bool {
-	return cell.State == UNSEEN || cell.State == OPEN
+	return cell.State != DISABLED
 }
 
-func calcHeuristic(curX int, curY int, targetX int, targetY int) int {
-	// Manhattan
-	return int(10*math.Abs(float64(curX-targetX)) + 10*math.Abs(float64(curY-targetY)))
+// NewGrid - width x height grid of unseen cells
+func NewGrid(width int, height int) Grid {
+	grid := make(Grid, height)
+
+	for y := range grid {
+		grid[y] = make([]*Cell, width)
+
+		for x := range grid[y] {
+			grid[y][x] = &Cell{X: x, Y: y, State: UNSEEN, Row: y, Col: x}
+		}
+	}
+
+	return grid
+}
+
+func (g Grid) InBounds(x int, y int) bool {
+	return y >= 0 && y < len(g) && x >= 0 && x < len(g[y])
 }
 
-func getLowestFScoreElement(openList *list.List) *list.Element {
-	if openList.Len() == 0 {
-		return nil
+// Reset - clears marks such as PATH from the cells, keeping walls
+func (g Grid) Reset() {
+	for y := range g {
+		for x := range g[y] {
+			if g[y][x].State != DISABLED {
+				g[y][x].State = UNSEEN
+			}
+		}
 	}
+}
 
-	lowestElem := openList.Front()
+// MarkPath - sets the state of every cell on path, e.g. PATH for drawing. Reset
+// clears the marks again, unless state is DISABLED.
+func (g Grid) MarkPath(path []*Cell, state CellState) {
+	for _, cell := range path {
+		g[cell.Row][cell.Col].State = state
+	}
+}
 
-	for e := lowestElem.Next(); e != nil; e = e.Next() {
-		cell := e.Value.(*Cell)
+// Clone - deep copy of the grid, sharing no cells with the original
+func (g Grid) Clone() Grid {
+	clone := make(Grid, len(g))
 
-		if cell.F() < lowestElem.Value.(*Cell).F() {
-			lowestElem = e
+	for y := range g {
+		clone[y] = make([]*Cell, len(g[y]))
+
+		for x := range g[y] {
+			cell := *g[y][x]
+			clone[y][x] = &cell
 		}
 	}
 
-	return lowestElem
+	return clone
+}
+
+// Heuristic - estimated cost from one grid point to another
+type Heuristic func(from Point, to Point) int
+
+// Manhattan - 10 per column plus 10 per row
+func Manhattan(from Point, to Point) int {
+	return calcHeuristic(from.X, from.Y, to.X, to.Y)
+}
+
+func calcHeuristic(curX int, curY int, targetX int, targetY int) int {
+	// Manhattan
+	return int(10*math.Abs(float64(curX-targetX)) + 10*math.Abs(float64(curY-targetY)))
 }
 
 func GetNeighbourCells(grid Grid, cell *Cell) ([]*Cell, []int) {
-	var neighbours [8]*Cell
-	var costs [8]int
-	neighbourCount := 0
+	return appendNeighbourCells(grid, cell, make([]*Cell, 0, 8), make([]int, 0, 8))
+}
 
-	// left
-	if cell.X > 0 && grid[cell.Y][cell.X-1].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y][cell.X-1]
-		costs[neighbourCount] = 10
+// appendNeighbourCells - GetNeighbourCells appending to the given slices, so a
+// search can keep reusing the same ones
+func appendNeighbourCells(grid Grid, cell *Cell, neighbours []*Cell, costs []int) ([]*Cell, []int) {
+	x, y := cell.Col, cell.Row
+	first := len(neighbours)
 
-		neighbourCount++
+	// left
+	if x > 0 && grid[y][x-1].Walkable() {
+		neighbours = append(neighbours, grid[y][x-1])
+		costs = append(costs, 10)
 	}
 
 	// upper left
-	if cell.X > 0 && cell.Y+1 < len(grid) && grid[cell.Y+1][cell.X-1].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y+1][cell.X-1]
-		costs[neighbourCount] = 14
-
-		neighbourCount++
+	if x > 0 && y+1 < len(grid) && grid[y+1][x-1].Walkable() {
+		neighbours = append(neighbours, grid[y+1][x-1])
+		costs = append(costs, 14)
 	}
 
 	// top
-	if cell.Y+1 < len(grid) && grid[cell.Y+1][cell.X].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y+1][cell.X]
-		costs[neighbourCount] = 10
-
-		neighbourCount++
+	if y+1 < len(grid) && grid[y+1][x].Walkable() {
+		neighbours = append(neighbours, grid[y+1][x])
+		costs = append(costs, 10)
 	}
 
 	// top right
-	if cell.Y+1 < len(grid) && cell.X+1 < len(grid[cell.Y+1]) && grid[cell.Y+1][cell.X].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y+1][cell.X+1]
-		costs[neighbourCount] = 14
-
-		neighbourCount++
+	if y+1 < len(grid) && x+1 < len(grid[y+1]) && grid[y+1][x+1].Walkable() {
+		neighbours = append(neighbours, grid[y+1][x+1])
+		costs = append(costs, 14)
 	}
 
 	// right
-	if cell.X+1 < len(grid[cell.Y]) && grid[cell.Y][cell.X+1].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y][cell.X+1]
-		costs[neighbourCount] = 10
-
-		neighbourCount++
+	if x+1 < len(grid[y]) && grid[y][x+1].Walkable() {
+		neighbours = append(neighbours, grid[y][x+1])
+		costs = append(costs, 10)
 	}
 
 	// bottom right
-	if cell.X+1 < len(grid[cell.Y]) && cell.Y > 0 && grid[cell.Y][cell.X+1].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y-1][cell.X+1]
-		costs[neighbourCount] = 14
-
-		neighbourCount++
+	if x+1 < len(grid[y]) && y > 0 && grid[y-1][x+1].Walkable() {
+		neighbours = append(neighbours, grid[y-1][x+1])
+		costs = append(costs, 14)
 	}
 
 	// bottom
-	if cell.Y > 0 && grid[cell.Y-1][cell.X].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y-1][cell.X]
-		costs[neighbourCount] = 10
-
-		neighbourCount++
+	if y > 0 && grid[y-1][x].Walkable() {
+		neighbours = append(neighbours, grid[y-1][x])
+		costs = append(costs, 10)
 	}
 
 	// bottom left
-	if cell.X > 0 && cell.Y > 0 && grid[cell.Y-1][cell.X-1].Walkable() {
-		neighbours[neighbourCount] = grid[cell.Y-1][cell.X-1]
-		costs[neighbourCount] = 14
+	if x > 0 && y > 0 && grid[y-1][x-1].Walkable() {
+		neighbours = append(neighbours, grid[y-1][x-1])
+		costs = append(costs, 14)
+	}
 
-		neighbourCount++
+	// Drop the moves thin walls block
+	count := first
+	for n := first; n < len(neighbours); n++ {
+		if !edgeWall(cell, neighbours[n]) {
+			neighbours[count], costs[count] = neighbours[n], costs[n]
+			count++
+		}
 	}
 
-	return neighbours[:neighbourCount], costs[:neighbourCount]
+	return neighbours[:count], costs[:count]
 }
 
-func ProcessNeighbours(curCell *Cell, targetX int, targetY int, grid Grid, openCells *list.List) {
-	neighbours, costs := GetNeighbourCells(grid, curCell)
+func (st *Stepper) ProcessNeighbours(curCell *Cell) {
+	s := st.solver
+	cur := st.node(curCell)
+	neighbours, costs := s.neighbours(curCell, st.neighbourBuf[:0], st.costBuf[:0])
+
+	if s.SortNeighbours {
+		st.sortNeighbours(neighbours, costs)
+	}
 
 	for n := range neighbours {
-		newG := curCell.G + costs[n]
+		if s.NeighbourFilter != nil && !s.NeighbourFilter(curCell, neighbours[n]) {
+			continue
+		}
+
+		if s.MaxDiagonalRun > 0 && isDiagonal(curCell, neighbours[n]) && cur.Diagonals >= s.MaxDiagonalRun {
+			continue
+		}
 
-		if neighbours[n].State == OPEN && newG < neighbours[n].G {
+		if s.RequireLOSFromStart && !LineOfSight(s.Grid, st.start, neighbours[n].Index()) {
+			continue
+		}
+
+		if !s.fits(neighbours[n]) {
+			continue
+		}
+
+		if !s.canClimb(curCell, neighbours[n]) {
+			continue
+		}
+
+		extra, ok := s.congestionCost(neighbours[n])
+		if !ok {
+			// Cell is full, nobody else fits in there
+			continue
+		}
+
+		exposed, ok := s.exposedCost(curCell, neighbours[n])
+		if !ok {
+			// No cover on that side
+			continue
+		}
+
+		newG := cur.G + s.fatigued(cur, s.cellCost(neighbours[n], s.stepCost(curCell, neighbours[n], costs[n]))) + extra + exposed +
+			s.turnCost(cur, neighbours[n]) + s.cornerCost(curCell, neighbours[n]) +
+			st.backtrackCost(cur, neighbours[n]) + s.directionCost(curCell, neighbours[n]) +
+			s.surcharge[neighbours[n].Index()] + neighbours[n].Weight
+		newG = cur.G + s.discounted(neighbours[n], newG-cur.G)
+
+		if newG < neighbours[n].MinEnterG {
+			// Gate is still shut at this cost
+			st.gated = true
+			continue
+		}
+
+		next := st.node(neighbours[n])
+
+		if next.State == OPEN && (newG < next.G || (newG == next.G && st.moreDiagonal(cur, next))) {
 			// If neighbour is already in the open list
 			// then check if my G + cost to that node < its existing G,
 			// and if so, update that neighbour and set parent to me
-			neighbours[n].G = newG
-			neighbours[n].Parent = curCell
-		} else if neighbours[n].State == UNSEEN {
+			next.G = newG
+			setParent(next, cur)
+
+			st.open.Update(next.Cell, st.priority(next))
+		} else if next.State == CLOSED && newG < next.G {
+			// Only happens with an inconsistent heuristic, the cell was expanded
+			// too soon and goes back on the open list
+			if s.MaxReopens > 0 && next.Reopens >= s.MaxReopens {
+				st.reopensCapped = true
+				continue
+			}
+
+			next.G = newG
+			next.State = OPEN
+			next.Reopens++
+			setParent(next, cur)
+
+			st.open.Push(next.Cell, st.priority(next))
+		} else if next.State == UNSEEN {
 			// If my neighbour is not already on the open list, calculate G and H and add it to the open list
-			neighbours[n].G = newG
-			neighbours[n].H = calcHeuristic(neighbours[n].X, neighbours[n].Y, targetX, targetY)
-			neighbours[n].State = OPEN
-			neighbours[n].Parent = curCell
+			next.G = newG
+			next.State = OPEN
+
+			if s.LazyHeuristic {
+				// Anything lower than this would make the heuristic inconsistent,
+				// the real value waits until the cell comes off the open list
+				next.H = max(cur.H-(newG-cur.G), 0)
+				next.Estimated = true
+			} else {
+				next.H = s.heuristic(neighbours[n].Index(), st.target)
+			}
+
+			setParent(next, cur)
 
-			openCells.PushBack(neighbours[n])
+			st.open.Push(next.Cell, st.priority(next))
 		}
 	}
 }
 
+// sortNeighbours - orders neighbours and their costs by base cost plus heuristic,
+// then by heuristic. An insertion sort as there are at most 8 of them.
+func (st *Stepper) sortNeighbours(neighbours []*Cell, costs []int) {
+	var f, h [8]int
+	for n := range neighbours {
+		h[n] = st.solver.heuristic(neighbours[n].Index(), st.target)
+		f[n] = costs[n] + h[n]
+	}
+
+	less := func(i, j int) bool {
+		return f[i] < f[j] || (f[i] == f[j] && h[i] < h[j])
+	}
+
+	for i := 1; i < len(neighbours); i++ {
+		for j := i; j > 0 && less(j, j-1); j-- {
+			f[j], f[j-1] = f[j-1], f[j]
+			h[j], h[j-1] = h[j-1], h[j]
+			costs[j], costs[j-1] = costs[j-1], costs[j]
+			neighbours[j], neighbours[j-1] = neighbours[j-1], neighbours[j]
+		}
+	}
+}
+
+// setParent - records the move from parent into n
+func setParent(n *node, parent *node) {
+	n.Parent = parent
+	n.Depth = parent.Depth + 1
+	n.Heading = directionOf(parent.Cell, n.Cell)
+
+	if isDiagonal(parent.Cell, n.Cell) {
+		n.Diagonals = parent.Diagonals + 1
+		n.DiagonalMoves = parent.DiagonalMoves + 1
+	} else {
+		n.Diagonals = 0
+		n.DiagonalMoves = parent.DiagonalMoves
+	}
+}
+
+func isDiagonal(from *Cell, to *Cell) bool {
+	return from.Col != to.Col && from.Row != to.Row
+}
+
 func PrintGrid(startX int, startY int, targetX int, targetY int, grid Grid) {
+	fmt.Print(renderGrid(Point{startX, startY}, Point{targetX, targetY}, grid, nil))
+}
+
+// renderGrid - grid as text, one line per row. Given the nodes of a search, open
+// cells are drawn as [+] and closed ones as [-].
+func renderGrid(start Point, target Point, grid Grid, nodes [][]node) string {
+	var b strings.Builder
+
 	for y := range grid {
 		for x := range grid[y] {
-			if x == startX && y == startY {
-				fmt.Printf("[O] ")
-			} else if x == targetX && y == targetY {
-				fmt.Printf("[X] ")
+			if x == start.X && y == start.Y {
+				b.WriteString("[O] ")
+			} else if x == target.X && y == target.Y {
+				b.WriteString("[X] ")
 			} else if grid[y][x].State == PATH {
-				fmt.Printf("[*] ")
+				b.WriteString("[*] ")
 			} else if grid[y][x].State == DISABLED {
-				fmt.Printf("[|] ")
+				b.WriteString("[|] ")
+			} else if nodes != nil && nodes[y][x].State == OPEN {
+				b.WriteString("[+] ")
+			} else if nodes != nil && nodes[y][x].State == CLOSED {
+				b.WriteString("[-] ")
 			} else {
-				fmt.Printf("[ ] ")
+				b.WriteString("[ ] ")
 			}
 		}
 
-		fmt.Printf("\n")
+		b.WriteString("\n")
 	}
+
+	return b.String()
 }
 
 func main() {
@@ -181,58 +415,23 @@ func main() {
 	gridWidth := 7
 	gridHeight := 5
 
-	startX := 1
-	startY := 2
-
-	targetX := 5
-	targetY := 2
+	start := Point{1, 2}
+	target := Point{5, 2}
 
-	grid := make([][]*Cell, gridHeight)
-
-	for y := range grid {
-		grid[y] = make([]*Cell, gridWidth)
-
-		for x := range grid[y] {
-			h := calcHeuristic(x, y, targetX, targetY)
-			grid[y][x] = &Cell{x, y, h, 0, UNSEEN, nil}
-		}
-	}
+	grid := NewGrid(gridWidth, gridHeight)
 
 	// Make a wall
 	grid[1][3].State = DISABLED
 	grid[2][3].State = DISABLED
 	grid[3][3].State = DISABLED
 
-	// Init the starting cell
-	startCell := grid[startY][startX]
-	startCell.H = 0
-
-	// Add the start cell to the list of open cells
-	openCells := list.New()
-	openCells.PushBack(startCell)
-
-	for openCells.Len() > 0 {
-		lowestElem := getLowestFScoreElement(openCells)
-		if lowestElem == nil {
-			panic(fmt.Sprintf("No lowest elem found"))
-		}
-
-		// Remove the lowest cost element of the open list
-		openCells.Remove(lowestElem)
-
-		curCell := lowestElem.Value.(*Cell)
-		curCell.State = CLOSED
-
-		if curCell.X == targetX && curCell.Y == targetY {
-			for curCell != nil {
-				curCell.State = PATH
-				curCell = curCell.Parent
-			}
+	path, err := FindPath(grid, start, target)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 
-			PrintGrid(startX, startY, targetX, targetY, grid)
-			return
-		}
+	grid.MarkPath(path, PATH)
 
-		ProcessNeighbours(curCell, targetX, targetY, grid, openCells)
-	}
+	PrintGrid(start.X, start.Y, target.X, target.Y, grid)
 }
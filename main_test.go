@@ -0,0 +1,242 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// parseGrid - grid drawn as text, one string per row: '#' is a wall, anything
+// else open ground
+func parseGrid(rows ...string) Grid {
+	grid := NewGrid(len(rows[0]), len(rows))
+
+	for y, row := range rows {
+		for x, c := range row {
+			if c == '#' {
+				grid[y][x].State = DISABLED
+			}
+		}
+	}
+
+	return grid
+}
+
+func containsPoint(path []*Cell, p Point) bool {
+	for _, cell := range path {
+		if cell.Index() == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestFindPathAroundWall(t *testing.T) {
+	grid := parseGrid(
+		".......",
+		"...#...",
+		"...#...",
+		"...#...",
+		".......",
+	)
+
+	path, err := FindPath(grid, Point{1, 2}, Point{5, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path[0].Index() != (Point{1, 2}) || path[len(path)-1].Index() != (Point{5, 2}) {
+		t.Fatalf("path %v doesn't run from start to target", PathPoints(path))
+	}
+
+	for _, cell := range path {
+		if !cell.Walkable() {
+			t.Fatalf("path %v goes through wall %v", PathPoints(path), cell.Index())
+		}
+	}
+
+	if !strings.Contains(renderGrid(Point{1, 2}, Point{5, 2}, grid, nil), "[|]") {
+		t.Fatal("walls missing from the rendered grid")
+	}
+}
+
+// checkContiguous - fails unless every cell of path is a neighbour of the one
+// before it
+func checkContiguous(t *testing.T, path []*Cell) {
+	t.Helper()
+
+	for i := 1; i < len(path); i++ {
+		if abs(path[i].Col-path[i-1].Col) > 1 || abs(path[i].Row-path[i-1].Row) > 1 || path[i] == path[i-1] {
+			t.Fatalf("path %v jumps from %v to %v", PathPoints(path), path[i-1].Index(), path[i].Index())
+		}
+	}
+}
+
+// moveCost - cost of path under the default move costs
+func moveCost(path []*Cell) int {
+	cost := 0
+	for i := 1; i < len(path); i++ {
+		if isDiagonal(path[i-1], path[i]) {
+			cost += 14
+		} else {
+			cost += 10
+		}
+	}
+
+	return cost
+}
+
+func TestMarkPathCustomState(t *testing.T) {
+	grid := parseGrid(
+		"....",
+		".#..",
+		"....",
+	)
+
+	path, err := FindPath(grid, Point{0, 0}, Point{3, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const highlight CellState = 7
+	grid.MarkPath(path, highlight)
+
+	for y := range grid {
+		for x := range grid[y] {
+			onPath := containsPoint(path, Point{x, y})
+
+			if onPath && grid[y][x].State != highlight {
+				t.Fatalf("path cell %d, %d has state %v, want %v", x, y, grid[y][x].State, highlight)
+			}
+			if !onPath && grid[y][x].State == highlight {
+				t.Fatalf("cell %d, %d is marked but not on the path", x, y)
+			}
+		}
+	}
+
+	grid.Reset()
+
+	for y := range grid {
+		for x := range grid[y] {
+			want := CellState(UNSEEN)
+			if x == 1 && y == 1 {
+				want = DISABLED
+			}
+
+			if grid[y][x].State != want {
+				t.Fatalf("cell %d, %d has state %v after Reset, want %v", x, y, grid[y][x].State, want)
+			}
+		}
+	}
+}
+
+// bruteForceShortest - cost of the cheapest path from start to target with
+// diagonal moves at the default costs, false without one. Relaxes every move of
+// every cell until nothing changes, sharing no code with the search.
+func bruteForceShortest(grid Grid, start Point, target Point) (int, bool) {
+	const unreached = -1
+
+	dist := make([][]int, len(grid))
+	for y := range grid {
+		dist[y] = make([]int, len(grid[y]))
+		for x := range dist[y] {
+			dist[y][x] = unreached
+		}
+	}
+
+	if grid[start.Y][start.X].State == DISABLED {
+		return 0, false
+	}
+	dist[start.Y][start.X] = 0
+
+	for changed := true; changed; {
+		changed = false
+
+		for y := range grid {
+			for x := range grid[y] {
+				if dist[y][x] == unreached {
+					continue
+				}
+
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						nx, ny := x+dx, y+dy
+						if (dx == 0 && dy == 0) || ny < 0 || ny >= len(grid) || nx < 0 || nx >= len(grid[ny]) || grid[ny][nx].State == DISABLED {
+							continue
+						}
+
+						cost := 10
+						if dx != 0 && dy != 0 {
+							cost = 14
+						}
+
+						if d := dist[y][x] + cost; dist[ny][nx] == unreached || d < dist[ny][nx] {
+							dist[ny][nx] = d
+							changed = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return dist[target.Y][target.X], dist[target.Y][target.X] != unreached
+}
+
+// randomGrid - width x height grid with about one cell in three a wall, start
+// and target picked at random and left open
+func randomGrid(rng *rand.Rand, width int, height int) (Grid, Point, Point) {
+	grid := NewGrid(width, height)
+	for y := range grid {
+		for x := range grid[y] {
+			if rng.Intn(3) == 0 {
+				grid[y][x].State = DISABLED
+			}
+		}
+	}
+
+	start := Point{rng.Intn(width), rng.Intn(height)}
+	target := Point{rng.Intn(width), rng.Intn(height)}
+	grid[start.Y][start.X].State = UNSEEN
+	grid[target.Y][target.X].State = UNSEEN
+
+	return grid, start, target
+}
+
+func FuzzFindPathMatchesBruteForce(f *testing.F) {
+	for seed := int64(0); seed < 500; seed++ {
+		f.Add(seed, uint8(2+seed%9), uint8(2+seed/9%9))
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, width uint8, height uint8) {
+		if width < 1 || height < 1 || width > 16 || height > 16 {
+			t.Skip()
+		}
+
+		grid, start, target := randomGrid(rand.New(rand.NewSource(seed)), int(width), int(height))
+		want, reachable := bruteForceShortest(grid, start, target)
+
+		s := NewSolver(grid)
+		path, err := s.FindPath(start, target)
+
+		if reachable != (err == nil) {
+			t.Fatalf("%v to %v: brute force reachable %v, search error %v\n%s", start, target, reachable, err, renderGrid(start, target, grid, nil))
+		}
+		if !reachable {
+			return
+		}
+
+		if s.Stats.Cost != want || moveCost(path) != want {
+			t.Fatalf("%v to %v: path %v costs %d (reported %d), brute force %d\n%s",
+				start, target, PathPoints(path), moveCost(path), s.Stats.Cost, want, renderGrid(start, target, grid, nil))
+		}
+
+		checkContiguous(t, path)
+		for _, cell := range path {
+			if !cell.Walkable() {
+				t.Fatalf("path %v goes through wall %v", PathPoints(path), cell.Index())
+			}
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+// MedialAxis - cells along the middle of the walkable space, as far from walls
+// as their surroundings allow, row by row. A cell is on the axis when its
+// distance to the nearest wall (or the edge of the grid) is at least that of
+// both neighbours in a row or both in a column, and more than one of them. Where
+// a passage is an even number of cells wide both middle cells are on it. Like a
+// true medial axis it branches off diagonally into the corners of rooms.
+func (g Grid) MedialAxis() []Point {
+	clearance := g.clearanceField()
+
+	at := func(x int, y int) int {
+		if !g.InBounds(x, y) {
+			return 0
+		}
+
+		return clearance[y][x]
+	}
+
+	ridge := func(c int, a int, b int) bool {
+		return c >= a && c >= b && (c > a || c > b)
+	}
+
+	var axis []Point
+
+	for y := range g {
+		for x := range g[y] {
+			c := clearance[y][x]
+			if c == 0 {
+				continue
+			}
+
+			if ridge(c, at(x-1, y), at(x+1, y)) || ridge(c, at(x, y-1), at(x, y+1)) {
+				axis = append(axis, Point{x, y})
+			}
+		}
+	}
+
+	return axis
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMedialAxisRoomCentre(t *testing.T) {
+	grid := NewGrid(9, 5)
+	axis := grid.MedialAxis()
+
+	on := make(map[Point]bool)
+	for _, p := range axis {
+		on[p] = true
+	}
+
+	for x := 2; x <= 6; x++ {
+		if !on[Point{x, 2}] {
+			t.Fatalf("centre line cell (%d, 2) missing from axis %v", x, axis)
+		}
+		if on[Point{x, 1}] || on[Point{x, 3}] {
+			t.Fatalf("axis %v strays off the centre line at column %d", axis, x)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"container/heap"
+)
+
+// FindPathMinimax - path from start to target whose dearest single move is as
+// cheap as possible, e.g. crossing a river at its shallowest point. A move costs
+// its normal cost plus the Weight of the cell it enters. Among paths with the
+// same dearest move any one may be returned, not necessarily the shortest.
+func FindPathMinimax(grid Grid, start Point, target Point) ([]*Cell, error) {
+	if !grid.InBounds(start.X, start.Y) || !grid.InBounds(target.X, target.Y) {
+		return nil, ErrOutOfBounds
+	}
+
+	startCell, targetCell := grid[start.Y][start.X], grid[target.Y][target.X]
+	if !startCell.Walkable() || !targetCell.Walkable() {
+		return nil, &NoPathError{}
+	}
+
+	s := NewSolver(grid)
+
+	// worst - dearest move on the best path found to each cell so far
+	worst := map[*Cell]int{startCell: 0}
+	parent := map[*Cell]*Cell{startCell: nil}
+	done := make(map[*Cell]bool)
+
+	queue := &distanceQueue{{cell: startCell}}
+	expansions := 0
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(distanceItem)
+		if done[item.cell] {
+			continue
+		}
+
+		done[item.cell] = true
+		expansions++
+
+		if item.cell == targetCell {
+			var path []*Cell
+			for cell := targetCell; cell != nil; cell = parent[cell] {
+				path = append(path, cell)
+			}
+
+			return reversePath(path), nil
+		}
+
+		neighbours, costs := s.neighbours(item.cell, nil, nil)
+
+		for n, neighbour := range neighbours {
+			value := max(item.dist, costs[n]+neighbour.Weight)
+
+			if old, seen := worst[neighbour]; !seen || value < old {
+				worst[neighbour] = value
+				parent[neighbour] = item.cell
+				heap.Push(queue, distanceItem{cell: neighbour, dist: value})
+			}
+		}
+	}
+
+	return nil, &NoPathError{Expansions: expansions}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindPathMinimaxShallowCrossing(t *testing.T) {
+	// A river down the middle column, shallower towards the banks' ends
+	grid := NewGrid(5, 3)
+	grid[0][2].Weight = 19
+	grid[1][2].Weight = 25
+	grid[2][2].Weight = 19
+
+	start, target := Point{0, 1}, Point{4, 1}
+
+	sum, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsPoint(sum, Point{2, 1}) {
+		t.Fatalf("cheapest path %v doesn't wade straight across", PathPoints(sum))
+	}
+
+	minimax, err := FindPathMinimax(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, minimax)
+	if containsPoint(minimax, Point{2, 1}) {
+		t.Fatalf("minimax path %v crosses at the deepest point", PathPoints(minimax))
+	}
+	if last := minimax[len(minimax)-1].Index(); last != target {
+		t.Fatalf("minimax path %v ends at %v", PathPoints(minimax), last)
+	}
+}
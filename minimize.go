@@ -0,0 +1,95 @@
+package main
+
+// MinimizeGrid - smaller copy of grid with the rows and columns removed that the
+// optimal start to target cost doesn't depend on, for turning a bad path report
+// into a small reproducer. Cells keep their world X, Y so start and target can be
+// found again. When there is no path the whole grid is copied.
+func MinimizeGrid(grid Grid, start Point, target Point) Grid {
+	s := NewSolver(grid)
+	if _, err := s.FindPath(start, target); err != nil || len(grid) == 0 {
+		return grid.Clone()
+	}
+
+	cost := s.Stats.Cost
+
+	rows := make([]int, len(grid))
+	for y := range rows {
+		rows[y] = y
+	}
+
+	cols := make([]int, len(grid[0]))
+	for x := range cols {
+		cols[x] = x
+	}
+
+	// Same cost as the original without this row or column?
+	keeps := func(rows []int, cols []int) bool {
+		sub, subStart, subTarget := subGrid(grid, rows, cols, start, target)
+
+		subSolver := NewSolver(sub)
+		_, err := subSolver.FindPath(subStart, subTarget)
+
+		return err == nil && subSolver.Stats.Cost == cost
+	}
+
+	for removed := true; removed; {
+		removed = false
+
+		for i := 0; i < len(rows); i++ {
+			if rows[i] == start.Y || rows[i] == target.Y {
+				continue
+			}
+
+			fewer := append(append([]int{}, rows[:i]...), rows[i+1:]...)
+			if keeps(fewer, cols) {
+				rows = fewer
+				removed = true
+				i--
+			}
+		}
+
+		for i := 0; i < len(cols); i++ {
+			if cols[i] == start.X || cols[i] == target.X {
+				continue
+			}
+
+			fewer := append(append([]int{}, cols[:i]...), cols[i+1:]...)
+			if keeps(rows, fewer) {
+				cols = fewer
+				removed = true
+				i--
+			}
+		}
+	}
+
+	sub, _, _ := subGrid(grid, rows, cols, start, target)
+	return sub
+}
+
+// subGrid - copy of the given rows and columns of grid, with start and target
+// moved to their indices in the copy
+func subGrid(grid Grid, rows []int, cols []int, start Point, target Point) (Grid, Point, Point) {
+	sub := make(Grid, len(rows))
+	subStart, subTarget := start, target
+
+	for y, row := range rows {
+		sub[y] = make([]*Cell, len(cols))
+
+		for x, col := range cols {
+			cell := *grid[row][col]
+			cell.Row = y
+			cell.Col = x
+
+			sub[y][x] = &cell
+
+			if row == start.Y && col == start.X {
+				subStart = Point{x, y}
+			}
+			if row == target.Y && col == target.X {
+				subTarget = Point{x, y}
+			}
+		}
+	}
+
+	return sub, subStart, subTarget
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMinimizeGridKeepsCost(t *testing.T) {
+	grid := parseGrid(
+		"..........",
+		"..........",
+		"...####...",
+		"......#...",
+		"..........",
+		"..........",
+	)
+	start, target := Point{1, 4}, Point{8, 1}
+
+	s := NewSolver(grid)
+	if _, err := s.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+	cost := s.Stats.Cost
+
+	small := MinimizeGrid(grid, start, target)
+
+	if len(small) > len(grid) || len(small[0]) > len(grid[0]) {
+		t.Fatalf("minimized to %dx%d, bigger than %dx%d", len(small[0]), len(small), len(grid[0]), len(grid))
+	}
+	if len(small)*len(small[0]) == len(grid)*len(grid[0]) {
+		t.Fatal("nothing was removed")
+	}
+
+	// Start and target are found again by their world position
+	var smallStart, smallTarget Point
+	for y := range small {
+		for x := range small[y] {
+			switch small[y][x].World() {
+			case start:
+				smallStart = Point{x, y}
+			case target:
+				smallTarget = Point{x, y}
+			}
+		}
+	}
+
+	sub := NewSolver(small)
+	if _, err := sub.FindPath(smallStart, smallTarget); err != nil {
+		t.Fatal(err)
+	}
+
+	if sub.Stats.Cost != cost {
+		t.Fatalf("minimized grid costs %d, original %d", sub.Stats.Cost, cost)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+)
+
+// MovementMode - which of the 8 surrounding cells count as neighbours
+type MovementMode int
+
+const (
+	DIAGONAL MovementMode = 0 // all 8
+	CARDINAL MovementMode = 1 // left, right, top and bottom only
+)
+
+// Octile - 10 per straight step plus 14 per diagonal step, exact on an open
+// grid with diagonal movement
+func Octile(from Point, to Point) int {
+	dx := abs(from.X - to.X)
+	dy := abs(from.Y - to.Y)
+
+	if dx < dy {
+		dx, dy = dy, dx
+	}
+
+	return 10*(dx-dy) + 14*dy
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+func WithMovement(m MovementMode) Option {
+	return func(s *Solver) {
+		s.Movement = m
+	}
+}
+
+// WithCosts - cost of a straight and of a diagonal move, 10 and 14 by default
+func WithCosts(straight int, diagonal int) Option {
+	return func(s *Solver) {
+		s.StraightCost = straight
+		s.DiagonalCost = diagonal
+	}
+}
+
+func WithHeuristic(h Heuristic) Option {
+	return func(s *Solver) {
+		s.Heuristic = h
+	}
+}
+
+// Validate - problems with the current settings. NewSolver stores them in Warnings,
+// call it again after changing fields by hand.
+func (s *Solver) Validate() []string {
+	var warnings []string
+
+	// A* counts on costs being positive and on no move being cheaper than a
+	// straight step
+	if s.StraightCost <= 0 || s.DiagonalCost <= 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"move costs must be above 0, straight is %d and diagonal is %d",
+			s.StraightCost, s.DiagonalCost))
+	} else if cheapest := s.cheapestDirectionCost(); s.StraightCost+cheapest <= 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"direction cost %d brings a straight move down to %d, move costs must stay above 0",
+			cheapest, s.StraightCost+cheapest))
+	} else if s.DiagonalCost < s.StraightCost {
+		warnings = append(warnings, fmt.Sprintf(
+			"diagonal cost %d is below straight cost %d, so going round a corner is cheaper than a step along its side and paths may not be shortest",
+			s.DiagonalCost, s.StraightCost))
+	}
+
+	// One diagonal step away: the heuristic has to agree with how it's reached
+	from, to := Point{0, 0}, Point{1, 1}
+	h := s.heuristic(from, to)
+
+	switch s.Movement {
+	case DIAGONAL:
+		if diagonal := s.stepCost(&Cell{}, &Cell{Row: 1, Col: 1}, s.DiagonalCost); h > diagonal {
+			warnings = append(warnings, fmt.Sprintf(
+				"heuristic estimates %d for one diagonal step costing %d, paths may not be shortest with diagonal movement (use Octile)",
+				h, diagonal))
+		}
+	case CARDINAL:
+		// Landmark estimates are lower bounds for any movement, just weaker on
+		// some cells
+		if s.landmarks != nil {
+			break
+		}
+
+		if straight := s.stepCost(&Cell{}, &Cell{Col: 1}, s.StraightCost) + s.stepCost(&Cell{}, &Cell{Row: 1}, s.StraightCost); h < straight {
+			warnings = append(warnings, fmt.Sprintf(
+				"heuristic estimates %d for a corner two straight steps away costing %d, it assumes diagonal moves cardinal movement doesn't have (use Manhattan)",
+				h, straight))
+		}
+	}
+
+	if s.TagDiscount != 0 && s.PreferredTag == NoTag {
+		warnings = append(warnings, fmt.Sprintf(
+			"tag discount %d does nothing for NoTag, it would go to every untagged cell", s.TagDiscount))
+	}
+
+	if s.AdaptiveWeight > 1 && s.MaxSteps <= 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"adaptive weight %v does nothing without MaxSteps", s.AdaptiveWeight))
+	}
+
+	if s.TurnWeight > 0 && (s.Fatigue != 0 || s.MaxDiagonalRun > 0 || s.PreferDiagonals) {
+		warnings = append(warnings, fmt.Sprintf(
+			"turn weight %d searches by heading, which leaves out Fatigue, MaxDiagonalRun and PreferDiagonals", s.TurnWeight))
+	}
+
+	if s.PreferDiagonals && s.NewOpenSet != nil {
+		if _, ok := s.NewOpenSet().(TieBreaker); !ok {
+			warnings = append(warnings,
+				"prefer diagonals does nothing with an open set that can't break ties, it has to be a TieBreaker")
+		}
+	}
+
+	return warnings
+}
+
+// neighbours - GetNeighbourCells limited to the moves Movement allows, priced
+// with StraightCost and DiagonalCost, or Neighbours as it prices them less the
+// moves edge walls block, appended to the given empty slices
+func (s *Solver) neighbours(cell *Cell, neighbours []*Cell, costs []int) ([]*Cell, []int) {
+	if s.Neighbours != nil {
+		cells, cellCosts := s.Neighbours(s.Grid, cell)
+
+		for n := range cells {
+			if !edgeWall(cell, cells[n]) {
+				neighbours = append(neighbours, cells[n])
+				costs = append(costs, cellCosts[n])
+			}
+		}
+
+		return neighbours, costs
+	}
+
+	neighbours, costs = appendNeighbourCells(s.Grid, cell, neighbours, costs)
+	count := 0
+
+	for n := range neighbours {
+		if !isDiagonal(cell, neighbours[n]) {
+			neighbours[count] = neighbours[n]
+			costs[count] = s.StraightCost
+			count++
+		} else if s.Movement != CARDINAL {
+			neighbours[count] = neighbours[n]
+			costs[count] = s.DiagonalCost
+			count++
+		}
+	}
+
+	return neighbours[:count], costs[:count]
+}
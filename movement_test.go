@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateNonMetricCosts(t *testing.T) {
+	s := NewSolver(NewGrid(3, 3), WithCosts(10, 5))
+	if !hasWarning(s.Warnings, "diagonal cost 5 is below straight cost 10") {
+		t.Fatalf("warnings %v, want one about the cheap diagonal", s.Warnings)
+	}
+
+	s = NewSolver(NewGrid(3, 3), WithCosts(0, 14))
+	if !hasWarning(s.Warnings, "must be above 0") {
+		t.Fatalf("warnings %v, want one about the zero cost", s.Warnings)
+	}
+
+	if s := NewSolver(NewGrid(3, 3)); len(s.Warnings) != 0 {
+		t.Fatalf("default costs warn %v", s.Warnings)
+	}
+}
+
+func hasWarning(warnings []string, text string) bool {
+	for _, w := range warnings {
+		if strings.Contains(w, text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestValidatePreferDiagonalsOpenSet(t *testing.T) {
+	prefer := func(s *Solver) { s.PreferDiagonals = true }
+
+	s := NewSolver(NewGrid(3, 3), prefer, WithOpenSet(func() OpenSet { return &sliceOpenSet{} }))
+	if !hasWarning(s.Warnings, "prefer diagonals does nothing") {
+		t.Fatalf("warnings %v, want one about the open set", s.Warnings)
+	}
+
+	if s := NewSolver(NewGrid(3, 3), prefer, WithOpenSet(NewBucketQueue)); len(s.Warnings) != 0 {
+		t.Fatalf("bucket queue warns %v", s.Warnings)
+	}
+}
+
+func TestValidateTurnWeightLeavesOutFatigue(t *testing.T) {
+	fatigue := func(s *Solver) { s.Fatigue = 0.1 }
+
+	if s := NewSolver(NewGrid(3, 3), WithTurnWeight(5), fatigue); !hasWarning(s.Warnings, "leaves out Fatigue") {
+		t.Fatalf("warnings %v, want one about Fatigue", s.Warnings)
+	}
+
+	if s := NewSolver(NewGrid(3, 3), WithTurnWeight(5)); len(s.Warnings) != 0 {
+		t.Fatalf("turn weight alone warns %v", s.Warnings)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAsymmetricNeighbours - returned when a neighbour function lets a move be
+// made one way but not back, or at a different cost
+var ErrAsymmetricNeighbours = errors.New("asymmetric neighbours")
+
+// NeighbourFunc - neighbours of a cell and the cost of moving to each, like
+// GetNeighbourCells
+type NeighbourFunc func(grid Grid, cell *Cell) ([]*Cell, []int)
+
+// CheckNeighbourSymmetry - makes sure that whenever n gives b as a neighbour of
+// a, it also gives a as a neighbour of b at the same cost, as the search
+// assumes. The error names the first pair that breaks the rule.
+func CheckNeighbourSymmetry(grid Grid, n NeighbourFunc) error {
+	for y := range grid {
+		for x := range grid[y] {
+			a := grid[y][x]
+			if !a.Walkable() {
+				continue
+			}
+
+			neighbours, costs := n(grid, a)
+
+			for i, b := range neighbours {
+				back, ok := neighbourCost(grid, n, b, a)
+
+				if !ok {
+					return fmt.Errorf("%w: %v neighbours %v but not the other way round",
+						ErrAsymmetricNeighbours, a.Index(), b.Index())
+				}
+
+				if back != costs[i] {
+					return fmt.Errorf("%w: %v to %v costs %d but %d back",
+						ErrAsymmetricNeighbours, a.Index(), b.Index(), costs[i], back)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// neighbourCost - cost n gives the move from one cell to another, false when it
+// isn't a neighbour
+func neighbourCost(grid Grid, n NeighbourFunc, from *Cell, to *Cell) (int, bool) {
+	neighbours, costs := n(grid, from)
+
+	for i, cell := range neighbours {
+		if cell == to {
+			return costs[i], true
+		}
+	}
+
+	return 0, false
+}
+
+// Neighbour - a neighbour of a cell, the cost of moving there and whether it
+// touches a wall, diagonally included
+type Neighbour struct {
+	Cell     *Cell
+	Cost     int
+	NearWall bool
+}
+
+// GetNeighbours - GetNeighbourCells as Neighbours
+func GetNeighbours(grid Grid, cell *Cell) []Neighbour {
+	cells, costs := GetNeighbourCells(grid, cell)
+	neighbours := make([]Neighbour, len(cells))
+
+	for i, neighbour := range cells {
+		neighbours[i] = Neighbour{Cell: neighbour, Cost: costs[i], NearWall: nearWall(grid, neighbour)}
+	}
+
+	return neighbours
+}
+
+// nearWall - whether any of the 8 cells around cell is a wall
+func nearWall(grid Grid, cell *Cell) bool {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			x, y := cell.Col+dx, cell.Row+dy
+
+			if grid.InBounds(x, y) && grid[y][x].State == DISABLED {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// PathStillValid - whether path can still be walked on grid as it is now: every
+// cell walkable and each one a neighbour of the one before under
+// GetNeighbourCells, e.g. to tell if a cached path needs planning again. See
+// Solver.PathStillValid for the solver's own movement rules.
+func PathStillValid(grid Grid, path []*Cell) bool {
+	for i, cell := range path {
+		if !grid.InBounds(cell.Col, cell.Row) || grid[cell.Row][cell.Col] != cell || !cell.Walkable() {
+			return false
+		}
+
+		if i > 0 {
+			if _, ok := neighbourCost(grid, GetNeighbourCells, path[i-1], cell); !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// PathStillValid - PathStillValid under the movement rules of s: Movement,
+// Neighbours, MaxClimb and NeighbourFilter as well as walls and edge walls
+func (s *Solver) PathStillValid(path []*Cell) bool {
+	neighbours := func(grid Grid, cell *Cell) ([]*Cell, []int) {
+		return s.neighbours(cell, nil, nil)
+	}
+
+	for i, cell := range path {
+		if !s.Grid.InBounds(cell.Col, cell.Row) || s.Grid[cell.Row][cell.Col] != cell || !cell.Walkable() {
+			return false
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		from := path[i-1]
+		if !s.canClimb(from, cell) || (s.NeighbourFilter != nil && !s.NeighbourFilter(from, cell)) {
+			return false
+		}
+
+		if _, ok := neighbourCost(s.Grid, neighbours, from, cell); !ok {
+			return false
+		}
+	}
+
+	return true
+}
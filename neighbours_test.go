@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// wrapNeighbours - GetNeighbourCells plus a move between the two ends of every
+// row, as on a map that wraps round left to right
+func wrapNeighbours(grid Grid, cell *Cell) ([]*Cell, []int) {
+	neighbours, costs := GetNeighbourCells(grid, cell)
+	row := grid[cell.Row]
+
+	var other *Cell
+	switch cell.Col {
+	case 0:
+		other = row[len(row)-1]
+	case len(row) - 1:
+		other = row[0]
+	}
+
+	if other != nil && other.Walkable() {
+		neighbours = append(neighbours, other)
+		costs = append(costs, 10)
+	}
+
+	return neighbours, costs
+}
+
+func TestCheckNeighbourSymmetry(t *testing.T) {
+	grid := NewGrid(4, 3)
+
+	if err := CheckNeighbourSymmetry(grid, wrapNeighbours); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only ever forwards to the right
+	rightOnly := func(grid Grid, cell *Cell) ([]*Cell, []int) {
+		if cell.Col+1 < len(grid[cell.Row]) {
+			return []*Cell{grid[cell.Row][cell.Col+1]}, []int{10}
+		}
+
+		return nil, nil
+	}
+
+	err := CheckNeighbourSymmetry(grid, rightOnly)
+	if !errors.Is(err, ErrAsymmetricNeighbours) {
+		t.Fatalf("got %v, want ErrAsymmetricNeighbours", err)
+	}
+	if !strings.Contains(err.Error(), "{0 0} neighbours {1 0}") {
+		t.Fatalf("error %q doesn't name the pair (0, 0) and (1, 0)", err)
+	}
+}
+
+func TestSolverNeighbours(t *testing.T) {
+	grid := NewGrid(10, 1)
+
+	s := NewSolver(grid)
+	s.Neighbours = wrapNeighbours
+
+	// Octile doesn't know about the wrap
+	s.Heuristic = func(from Point, to Point) int { return 0 }
+
+	path, err := s.FindPath(Point{1, 0}, Point{8, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []Point{{1, 0}, {0, 0}, {9, 0}, {8, 0}}; len(path) != len(want) || s.Stats.Cost != 30 {
+		t.Fatalf("path %v costs %d, want %v round the wrap for 30", PathPoints(path), s.Stats.Cost, want)
+	}
+}
+
+func TestGetNeighboursNearWall(t *testing.T) {
+	grid := parseGrid(
+		"....",
+		"...#",
+		"....",
+	)
+
+	neighbours := GetNeighbours(grid, grid[1][1])
+	if len(neighbours) != 8 {
+		t.Fatalf("got %d neighbours, want 8", len(neighbours))
+	}
+
+	for _, n := range neighbours {
+		// Column 2 touches the wall at (3, 1)
+		if want := n.Cell.Col == 2; n.NearWall != want {
+			t.Fatalf("neighbour %v NearWall is %v, want %v", n.Cell.Index(), n.NearWall, want)
+		}
+		if want := moveCost([]*Cell{grid[1][1], n.Cell}); n.Cost != want {
+			t.Fatalf("neighbour %v costs %d, want %d", n.Cell.Index(), n.Cost, want)
+		}
+	}
+}
+
+func TestPathStillValidAfterWallChange(t *testing.T) {
+	grid := NewGrid(5, 3)
+	path, err := FindPath(grid, Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !PathStillValid(grid, path) {
+		t.Fatalf("fresh path %v isn't valid", PathPoints(path))
+	}
+
+	path[2].State = DISABLED
+	if PathStillValid(grid, path) {
+		t.Fatalf("path %v still valid through the wall at %v", PathPoints(path), path[2].Index())
+	}
+
+	path[2].State = UNSEEN
+	grid.AddEdgeWall(path[1].Index(), path[2].Index())
+	if PathStillValid(grid, path) {
+		t.Fatalf("path %v still valid across an edge wall", PathPoints(path))
+	}
+}
+
+func TestSolverPathStillValid(t *testing.T) {
+	grid := NewGrid(3, 3)
+	diagonal := []*Cell{grid[0][0], grid[1][1], grid[2][2]}
+
+	if s := NewSolver(grid); !s.PathStillValid(diagonal) {
+		t.Fatal("diagonal path not valid with diagonal movement")
+	}
+
+	if s := NewSolver(grid, WithMovement(CARDINAL)); s.PathStillValid(diagonal) {
+		t.Fatal("diagonal path valid with cardinal movement")
+	}
+
+	s := NewSolver(grid)
+	s.MaxClimb = 1
+	grid[2][2].Elevation = 3
+
+	if s.PathStillValid(diagonal) {
+		t.Fatal("path valid up a climb of 3 with MaxClimb 1")
+	}
+	if !PathStillValid(grid, diagonal) {
+		t.Fatal("grid-level check knows nothing of MaxClimb, should still pass")
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+// OpenSet - cells waiting to be expanded, ordered by priority (F). Pop must hand
+// back a cell with the lowest priority, or nil when the set is empty; the
+// built-in sets break ties in the order cells were pushed.
+type OpenSet interface {
+	Push(cell *Cell, priority int)
+	Pop() *Cell
+	// Update - new priority for a cell already in the set
+	Update(cell *Cell, priority int)
+	Len() int
+}
+
+// TieBreaker - OpenSet that can order cells of equal priority by a second key,
+// lowest first, before falling back on push order. PreferDiagonals needs one,
+// the built-in sets all are.
+type TieBreaker interface {
+	// SetTieBreak - key read for each cell on Push and Update, nil for none
+	SetTieBreak(key func(cell *Cell) int)
+}
+
+// WithOpenSet - build each search's open set with newSet, NewHeapOpenSet by default
+func WithOpenSet(newSet func() OpenSet) Option {
+	return func(s *Solver) {
+		s.NewOpenSet = newSet
+	}
+}
+
+// HeapOpenSet - binary heap, the default OpenSet
+type HeapOpenSet struct {
+	items   openHeap
+	entries map[*Cell]*openEntry
+	pushed  int
+	tieKey  func(cell *Cell) int
+
+	// free - popped entries, handed out again by Push
+	free []*openEntry
+}
+
+func NewHeapOpenSet() OpenSet {
+	return &HeapOpenSet{entries: make(map[*Cell]*openEntry)}
+}
+
+type openEntry struct {
+	cell     *Cell
+	priority int
+	tie      int
+	seq      int
+	index    int
+}
+
+// tieOf - second key of cell, 0 without one
+func tieOf(key func(cell *Cell) int, cell *Cell) int {
+	if key == nil {
+		return 0
+	}
+
+	return key(cell)
+}
+
+func (h *HeapOpenSet) Push(cell *Cell, priority int) {
+	var entry *openEntry
+	if n := len(h.free); n > 0 {
+		entry = h.free[n-1]
+		h.free = h.free[:n-1]
+	} else {
+		entry = &openEntry{}
+	}
+
+	*entry = openEntry{cell: cell, priority: priority, tie: tieOf(h.tieKey, cell), seq: h.pushed}
+	h.pushed++
+	h.entries[cell] = entry
+
+	heap.Push(&h.items, entry)
+}
+
+func (h *HeapOpenSet) Pop() *Cell {
+	if len(h.items) == 0 {
+		return nil
+	}
+
+	entry := heap.Pop(&h.items).(*openEntry)
+	delete(h.entries, entry.cell)
+	h.free = append(h.free, entry)
+
+	return entry.cell
+}
+
+func (h *HeapOpenSet) Update(cell *Cell, priority int) {
+	entry := h.entries[cell]
+	entry.priority = priority
+	entry.tie = tieOf(h.tieKey, cell)
+
+	heap.Fix(&h.items, entry.index)
+}
+
+func (h *HeapOpenSet) Len() int {
+	return len(h.items)
+}
+
+func (h *HeapOpenSet) SetTieBreak(key func(cell *Cell) int) {
+	h.tieKey = key
+}
+
+// reset - empties the set, keeping its memory for the next search
+func (h *HeapOpenSet) reset() {
+	h.free = append(h.free, h.items...)
+	h.items = h.items[:0]
+	clear(h.entries)
+	h.pushed = 0
+}
+
+// openHeap - entries by priority, tie key, then push order, for container/heap
+type openHeap []*openEntry
+
+func (q openHeap) Len() int { return len(q) }
+
+func (q openHeap) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+
+	if q[i].tie != q[j].tie {
+		return q[i].tie < q[j].tie
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q openHeap) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *openHeap) Push(x interface{}) {
+	entry := x.(*openEntry)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *openHeap) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return entry
+}
+
+// ListOpenSet - unsorted list scanned for the lowest priority on every Pop.
+// Slow on big grids, but simple.
+type ListOpenSet struct {
+	cells   *list.List
+	entries map[*Cell]*list.Element
+	tieKey  func(cell *Cell) int
+}
+
+func NewListOpenSet() OpenSet {
+	return &ListOpenSet{cells: list.New(), entries: make(map[*Cell]*list.Element)}
+}
+
+func (l *ListOpenSet) Push(cell *Cell, priority int) {
+	l.entries[cell] = l.cells.PushBack(&openEntry{cell: cell, priority: priority, tie: tieOf(l.tieKey, cell)})
+}
+
+func (l *ListOpenSet) Pop() *Cell {
+	lowestElem := getLowestFScoreElement(l.cells)
+	if lowestElem == nil {
+		return nil
+	}
+	l.cells.Remove(lowestElem)
+
+	cell := lowestElem.Value.(*openEntry).cell
+	delete(l.entries, cell)
+
+	return cell
+}
+
+func (l *ListOpenSet) Update(cell *Cell, priority int) {
+	entry := l.entries[cell].Value.(*openEntry)
+	entry.priority = priority
+	entry.tie = tieOf(l.tieKey, cell)
+}
+
+func (l *ListOpenSet) Len() int {
+	return l.cells.Len()
+}
+
+func (l *ListOpenSet) SetTieBreak(key func(cell *Cell) int) {
+	l.tieKey = key
+}
+
+func getLowestFScoreElement(openList *list.List) *list.Element {
+	if openList.Len() == 0 {
+		return nil
+	}
+
+	lowestElem := openList.Front()
+
+	for e := lowestElem.Next(); e != nil; e = e.Next() {
+		entry := e.Value.(*openEntry)
+		lowest := lowestElem.Value.(*openEntry)
+
+		if entry.priority < lowest.priority || (entry.priority == lowest.priority && entry.tie < lowest.tie) {
+			lowestElem = e
+		}
+	}
+
+	return lowestElem
+}
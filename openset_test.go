@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// sliceOpenSet - the simplest OpenSet: a slice in push order, scanned on Pop
+type sliceOpenSet struct {
+	cells      []*Cell
+	priorities []int
+}
+
+func (o *sliceOpenSet) Push(cell *Cell, priority int) {
+	o.cells = append(o.cells, cell)
+	o.priorities = append(o.priorities, priority)
+}
+
+func (o *sliceOpenSet) Pop() *Cell {
+	lowest := 0
+	for i := range o.cells {
+		if o.priorities[i] < o.priorities[lowest] {
+			lowest = i
+		}
+	}
+
+	cell := o.cells[lowest]
+	o.cells = append(o.cells[:lowest], o.cells[lowest+1:]...)
+	o.priorities = append(o.priorities[:lowest], o.priorities[lowest+1:]...)
+
+	return cell
+}
+
+func (o *sliceOpenSet) Update(cell *Cell, priority int) {
+	for i := range o.cells {
+		if o.cells[i] == cell {
+			o.priorities[i] = priority
+		}
+	}
+}
+
+func (o *sliceOpenSet) Len() int {
+	return len(o.cells)
+}
+
+// countingOpenSet - OpenSet counting every Push, Pop and Update into ops
+type countingOpenSet struct {
+	OpenSet
+	ops *int
+}
+
+func (o countingOpenSet) Push(cell *Cell, priority int) {
+	*o.ops++
+	o.OpenSet.Push(cell, priority)
+}
+
+func (o countingOpenSet) Pop() *Cell {
+	*o.ops++
+	return o.OpenSet.Pop()
+}
+
+func (o countingOpenSet) Update(cell *Cell, priority int) {
+	*o.ops++
+	o.OpenSet.Update(cell, priority)
+}
+
+// checkSameSearch - fails unless every open set finds the same paths as
+// NewHeapOpenSet, expanding as many cells, on random grids
+func checkSameSearch(t *testing.T, sets map[string]func() OpenSet, opts ...Option) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 100; i++ {
+		grid, start, target := randomGrid(rng, 4+rng.Intn(12), 4+rng.Intn(12))
+
+		heap := NewSolver(grid, opts...)
+		want, wantErr := heap.FindPath(start, target)
+
+		for name, newSet := range sets {
+			s := NewSolver(grid, append(opts, WithOpenSet(newSet))...)
+			path, err := s.FindPath(start, target)
+
+			if (err == nil) != (wantErr == nil) || !reflect.DeepEqual(PathPoints(path), PathPoints(want)) || s.Stats.Expansions != heap.Stats.Expansions {
+				t.Fatalf("grid %d, %s: path %v (%v) after %d expansions, heap %v (%v) after %d", i, name,
+					PathPoints(path), err, s.Stats.Expansions, PathPoints(want), wantErr, heap.Stats.Expansions)
+			}
+		}
+	}
+}
+
+func TestOpenSetsFindSamePaths(t *testing.T) {
+	checkSameSearch(t, map[string]func() OpenSet{
+		"list":  NewListOpenSet,
+		"slice": func() OpenSet { return &sliceOpenSet{} },
+	})
+}
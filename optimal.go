@@ -0,0 +1,40 @@
+package main
+
+import (
+	"reflect"
+)
+
+// provablyOptimal - whether the settings guarantee the cheapest path, as far as
+// can be told without knowing what a custom heuristic or rule does. Holds for
+// the built-in heuristics when they fit the move costs, and with rules whose
+// cost of a move doesn't depend on the route taken to get there.
+func (s *Solver) provablyOptimal() bool {
+	if s.GoodEnoughH > 0 || s.goal != nil || s.PriorityBias != nil || s.Greedy || s.FirstPath ||
+		s.HeuristicWeight > 1 || (s.MaxSteps > 0 && s.AdaptiveWeight > 1) {
+		// Stops early or orders the open list by something other than F
+		return false
+	}
+
+	if s.CellCost != nil || s.Neighbours != nil {
+		// Can't tell whether its costs keep the heuristic a lower bound
+		return false
+	}
+
+	if s.MaxDiagonalRun > 0 || s.TurnCostFunc != nil || s.Fatigue != 0 {
+		// Depends on the route, of which each cell only keeps one
+		return false
+	}
+
+	w, h := s.cellSize()
+
+	switch reflect.ValueOf(s.Heuristic).Pointer() {
+	case reflect.ValueOf(Octile).Pointer():
+		return w == 1 && h == 1 && s.StraightCost >= 10 &&
+			(s.Movement == CARDINAL || s.DiagonalCost >= 14)
+	case reflect.ValueOf(Manhattan).Pointer():
+		return s.StraightCost >= 10 &&
+			(s.Movement == CARDINAL || (w == 1 && h == 1 && s.DiagonalCost >= 20))
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestStatsOptimal(t *testing.T) {
+	grid := NewGrid(20, 10)
+	scatterWalls(20, 10, func(x int, y int) { grid[y][x].State = DISABLED })
+	start, target := Point{0, 0}, Point{19, 9}
+
+	s := NewSolver(grid)
+	if _, err := s.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Stats.Optimal {
+		t.Fatal("plain A* with Octile isn't reported optimal")
+	}
+
+	s.HeuristicWeight = 2
+	if _, err := s.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+	if s.Stats.Optimal {
+		t.Fatal("weighted A* reported optimal")
+	}
+
+	s.HeuristicWeight = 1
+	s.Neighbours = GetNeighbourCells
+	if _, err := s.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+	if s.Stats.Optimal {
+		t.Fatal("custom Neighbours reported optimal")
+	}
+}
+
+func TestStatsOptimalFromCache(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		".#.##",
+		".#.##",
+		"...##",
+	)
+	grid[0][2].MinEnterG = 50
+
+	// Whether the gate kept the search from the cheapest path is only known
+	// from running it, the cache has to remember
+	s := NewSolver(grid, WithMovement(CARDINAL)).WithCache(4)
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.FindPath(Point{0, 0}, Point{4, 0}); err != nil {
+			t.Fatal(err)
+		}
+		if s.Stats.Optimal {
+			t.Fatalf("search %d past a MinEnterG gate reported optimal", i+1)
+		}
+	}
+
+	if s.Stats.Expansions != 0 {
+		t.Fatalf("second search expanded %d cells instead of using the cache", s.Stats.Expansions)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+)
+
+// ErrNoWaypoints - returned when a route is asked for through no waypoints
+var ErrNoWaypoints = errors.New("no waypoints given")
+
+// PatrolRoute - closed loop starting and ending at waypoints[0] that visits every
+// waypoint, in an order picked to keep the total cost low: nearest waypoint first,
+// then improved with 2-opt. Not guaranteed to be the best order.
+func PatrolRoute(grid Grid, waypoints []Point) ([]*Cell, error) {
+	if len(waypoints) == 0 {
+		return nil, ErrNoWaypoints
+	}
+
+	// Checked up front, a single waypoint never gets searched from
+	for _, p := range waypoints {
+		if !grid.InBounds(p.X, p.Y) {
+			return nil, ErrOutOfBounds
+		}
+
+		if !grid[p.Y][p.X].Walkable() {
+			return nil, &NoPathError{}
+		}
+	}
+
+	s := NewSolver(grid)
+	count := len(waypoints)
+
+	// Shortest path between every pair, only searched one way round
+	legs := make([][][]*Cell, count)
+	costs := make([][]int, count)
+
+	for i := range legs {
+		legs[i] = make([][]*Cell, count)
+		costs[i] = make([]int, count)
+	}
+
+	for i := 0; i < count; i++ {
+		for j := i + 1; j < count; j++ {
+			path, err := s.FindPath(waypoints[i], waypoints[j])
+			if err != nil {
+				return nil, err
+			}
+
+			legs[i][j] = path
+			costs[i][j] = s.Stats.Cost
+			costs[j][i] = costs[i][j]
+		}
+	}
+
+	// Nearest neighbour tour
+	tour := []int{0}
+	visited := make([]bool, count)
+	visited[0] = true
+
+	for len(tour) < count {
+		last := tour[len(tour)-1]
+		next := -1
+
+		for i := range waypoints {
+			if !visited[i] && (next == -1 || costs[last][i] < costs[last][next]) {
+				next = i
+			}
+		}
+
+		visited[next] = true
+		tour = append(tour, next)
+	}
+
+	// 2-opt, keeping the first waypoint in place
+	for improved := true; improved; {
+		improved = false
+
+		for i := 1; i < count-1; i++ {
+			for j := i + 1; j < count; j++ {
+				before, after := tour[i-1], tour[(j+1)%count]
+				delta := costs[before][tour[j]] + costs[tour[i]][after] -
+					costs[before][tour[i]] - costs[tour[j]][after]
+
+				if delta < 0 {
+					for a, b := i, j; a < b; a, b = a+1, b-1 {
+						tour[a], tour[b] = tour[b], tour[a]
+					}
+
+					improved = true
+				}
+			}
+		}
+	}
+
+	route := []*Cell{grid[waypoints[0].Y][waypoints[0].X]}
+
+	for i := range tour {
+		from, to := tour[i], tour[(i+1)%count]
+
+		if from == to {
+			continue
+		}
+
+		var leg []*Cell
+		if from < to {
+			leg = legs[from][to]
+		} else {
+			leg = reversePath(legs[to][from])
+		}
+
+		route = append(route, leg[1:]...)
+	}
+
+	return route, nil
+}
+
+// reversePath - copy of path running the other way
+func reversePath(path []*Cell) []*Cell {
+	reversed := make([]*Cell, len(path))
+
+	for i, cell := range path {
+		reversed[len(path)-1-i] = cell
+	}
+
+	return reversed
+}
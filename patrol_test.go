@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPatrolRouteCorners(t *testing.T) {
+	grid := NewGrid(10, 10)
+
+	// Corners in criss-cross order, walking them as given crosses the grid twice
+	waypoints := []Point{{0, 0}, {9, 9}, {9, 0}, {0, 9}}
+
+	naive, err := ConnectWaypoints(grid, append(waypoints, waypoints[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	route, err := PatrolRoute(grid, waypoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, route)
+
+	if route[0].Index() != waypoints[0] || route[len(route)-1].Index() != waypoints[0] {
+		t.Fatalf("route %v doesn't loop back to %v", PathPoints(route), waypoints[0])
+	}
+	for _, p := range waypoints {
+		if !containsPoint(route, p) {
+			t.Fatalf("route %v misses waypoint %v", PathPoints(route), p)
+		}
+	}
+
+	// Round the edge of the grid
+	if cost := moveCost(route); cost != 360 || cost >= moveCost(naive) {
+		t.Fatalf("route costs %d, want 360, below the %d of the given order", cost, moveCost(naive))
+	}
+}
+
+func TestPatrolRouteNoWaypoints(t *testing.T) {
+	if _, err := PatrolRoute(NewGrid(2, 2), nil); !errors.Is(err, ErrNoWaypoints) {
+		t.Fatalf("got %v, want ErrNoWaypoints", err)
+	}
+}
+
+func TestPatrolRouteBadWaypoint(t *testing.T) {
+	grid := parseGrid(
+		"..",
+		".#",
+	)
+
+	if _, err := PatrolRoute(grid, []Point{{5, 0}}); !errors.Is(err, ErrOutOfBounds) {
+		t.Fatalf("got %v for a waypoint off the grid, want ErrOutOfBounds", err)
+	}
+
+	var noPath *NoPathError
+	if _, err := PatrolRoute(grid, []Point{{1, 1}}); !errors.As(err, &noPath) {
+		t.Fatalf("got %v for a waypoint in a wall, want NoPathError", err)
+	}
+
+	if _, err := PatrolRoute(grid, []Point{{0, 0}, {1, 1}}); !errors.As(err, &noPath) {
+		t.Fatalf("got %v for a second waypoint in a wall, want NoPathError", err)
+	}
+}
+
+func TestPatrolRouteSingleWaypoint(t *testing.T) {
+	route, err := PatrolRoute(NewGrid(2, 2), []Point{{1, 0}})
+	if err != nil || len(route) != 1 || route[0].Index() != (Point{1, 0}) {
+		t.Fatalf("got %v (%v), want just the waypoint", route, err)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"math"
+)
+
+// CellToWorld - world (pixel) position of the centre of the cell at grid index
+// p, with the grid drawn from 0, 0 in square cells cellSize wide
+func (g Grid) CellToWorld(p Point, cellSize int) (float64, float64) {
+	return (float64(p.X) + 0.5) * float64(cellSize), (float64(p.Y) + 0.5) * float64(cellSize)
+}
+
+// WorldToCell - grid index of the cell containing world (pixel) position wx, wy,
+// the inverse of CellToWorld. False when it's outside the grid.
+func (g Grid) WorldToCell(wx float64, wy float64, cellSize int) (Point, bool) {
+	if cellSize <= 0 {
+		return Point{}, false
+	}
+
+	p := Point{int(math.Floor(wx / float64(cellSize))), int(math.Floor(wy / float64(cellSize)))}
+
+	return p, g.InBounds(p.X, p.Y)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCellToWorldRoundTrip(t *testing.T) {
+	grid := NewGrid(5, 4)
+
+	for _, p := range []Point{{0, 0}, {4, 0}, {2, 1}, {0, 3}, {4, 3}} {
+		wx, wy := grid.CellToWorld(p, 16)
+
+		if wx != float64(p.X*16+8) || wy != float64(p.Y*16+8) {
+			t.Fatalf("cell %v is at %v, %v, want its centre", p, wx, wy)
+		}
+
+		if back, ok := grid.WorldToCell(wx, wy, 16); !ok || back != p {
+			t.Fatalf("cell %v came back as %v (%v)", p, back, ok)
+		}
+	}
+
+	// Anywhere inside the cell, edges included on the low side
+	if p, ok := grid.WorldToCell(32, 47.9, 16); !ok || p != (Point{2, 2}) {
+		t.Fatalf("got %v (%v), want 2, 2", p, ok)
+	}
+}
+
+func TestWorldToCellOutOfBounds(t *testing.T) {
+	grid := NewGrid(5, 4)
+
+	for _, w := range [][2]float64{{-0.5, 10}, {10, -0.5}, {80, 10}, {10, 64}, {1000, 1000}} {
+		if p, ok := grid.WorldToCell(w[0], w[1], 16); ok {
+			t.Fatalf("%v, %v gave cell %v, want it outside the grid", w[0], w[1], p)
+		}
+	}
+
+	if _, ok := grid.WorldToCell(10, 10, 0); ok {
+		t.Fatal("cell size 0 gave a cell")
+	}
+}
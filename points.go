@@ -0,0 +1,52 @@
+package main
+
+// FindPathPoints - like FindPath but returns the grid indices of the path cells,
+// so the result holds no references into the grid
+func FindPathPoints(grid Grid, start Point, target Point) ([]Point, error) {
+	path, err := FindPath(grid, start, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return PathPoints(path), nil
+}
+
+// PathPoints - grid indices of the cells of path
+func PathPoints(path []*Cell) []Point {
+	points := make([]Point, len(path))
+
+	for i, cell := range path {
+		points[i] = cell.Index()
+	}
+
+	return points
+}
+
+// FindPathRelative - path between start and target given in the grid's own
+// frame, returned in world coordinates for a grid whose index 0, 0 currently
+// sits at origin, as with a scrolling world
+func FindPathRelative(grid Grid, origin Point, start Point, target Point) ([]Point, error) {
+	points, err := FindPathPoints(grid, start, target)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range points {
+		points[i].X += origin.X
+		points[i].Y += origin.Y
+	}
+
+	return points, nil
+}
+
+// TruncatePathAt - path up to and including the first cell stop holds for, the
+// whole path when it holds for none
+func TruncatePathAt(path []*Cell, stop func(*Cell) bool) []*Cell {
+	for i, cell := range path {
+		if stop(cell) {
+			return path[:i+1]
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindPathPointsSurviveReset(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		"..#..",
+		".....",
+	)
+
+	points, err := FindPathPoints(grid, Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := append([]Point(nil), points...)
+
+	cells, err := FindPath(grid, Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grid.MarkPath(cells, PATH)
+	grid.Reset()
+
+	if !reflect.DeepEqual(points, kept) {
+		t.Fatalf("points changed to %v after Reset, were %v", points, kept)
+	}
+
+	if !reflect.DeepEqual(points, PathPoints(cells)) {
+		t.Fatalf("points %v, cells at %v", points, PathPoints(cells))
+	}
+
+	// The cells are the grid's own and lose their marks
+	for _, cell := range cells {
+		if cell.State != UNSEEN {
+			t.Fatalf("cell %v kept state %v through Reset", cell.Index(), cell.State)
+		}
+	}
+}
+
+func TestFindPathRelativeAddsOrigin(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		"..#..",
+		".....",
+	)
+	origin := Point{100, -40}
+
+	local, err := FindPathPoints(grid, Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	world, err := FindPathRelative(grid, origin, Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := make([]Point, len(local))
+	for i, p := range local {
+		want[i] = Point{p.X + origin.X, p.Y + origin.Y}
+	}
+	if !reflect.DeepEqual(world, want) {
+		t.Fatalf("got %v, want %v", world, want)
+	}
+}
+
+func TestTruncatePathAt(t *testing.T) {
+	grid := NewGrid(6, 1)
+	path := []*Cell{grid[0][0], grid[0][1], grid[0][2], grid[0][3], grid[0][4], grid[0][5]}
+
+	// Stops at the first of the matching cells
+	truncated := TruncatePathAt(path, func(cell *Cell) bool { return cell.Col >= 3 })
+	if want := []Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}}; !reflect.DeepEqual(PathPoints(truncated), want) {
+		t.Fatalf("got %v, want %v", PathPoints(truncated), want)
+	}
+
+	if whole := TruncatePathAt(path, func(*Cell) bool { return false }); len(whole) != len(path) {
+		t.Fatalf("got %v with no match, want the whole path", PathPoints(whole))
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+// Portal - rectangle of walkable cells along a path, corner to corner, and the
+// edges the path enters and leaves it by. World coordinates with cell centres
+// on whole numbers, so the edges run along cell borders. The first rectangle is
+// entered at the centre of the start cell and the last one left at the centre
+// of the target, both ends of those edges being the same point.
+type Portal struct {
+	Min FPoint
+	Max FPoint
+
+	Entry [2]FPoint
+	Exit  [2]FPoint
+}
+
+// PathToPortals - path as a navmesh-style run of rectangles, each holding as
+// many consecutive path cells as fit in a walkable rectangle. Consecutive
+// rectangles meet along the edge between them, or only at a corner after a
+// diagonal move.
+func PathToPortals(grid Grid, path []*Cell) []Portal {
+	if len(path) == 0 {
+		return nil
+	}
+
+	// Rectangles by grid index, inclusive
+	type rect struct {
+		minX, minY, maxX, maxY int
+
+		// first - path cell the rectangle starts at
+		first *Cell
+	}
+
+	walkable := func(r rect) bool {
+		for y := r.minY; y <= r.maxY; y++ {
+			for x := r.minX; x <= r.maxX; x++ {
+				if !grid.InBounds(x, y) || !grid[y][x].Walkable() {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	first := path[0]
+	rects := []rect{{first.Col, first.Row, first.Col, first.Row, first}}
+
+	for _, cell := range path[1:] {
+		cur := &rects[len(rects)-1]
+		grown := rect{
+			min(cur.minX, cell.Col), min(cur.minY, cell.Row),
+			max(cur.maxX, cell.Col), max(cur.maxY, cell.Row),
+			cur.first,
+		}
+
+		if walkable(grown) {
+			*cur = grown
+		} else {
+			rects = append(rects, rect{cell.Col, cell.Row, cell.Col, cell.Row, cell})
+		}
+	}
+
+	// World position of grid index 0, 0
+	offX, offY := float64(first.X-first.Col), float64(first.Y-first.Row)
+
+	centre := func(cell *Cell) [2]FPoint {
+		p := FPoint{float64(cell.X), float64(cell.Y)}
+		return [2]FPoint{p, p}
+	}
+
+	corners := func(r rect) (FPoint, FPoint) {
+		return FPoint{offX + float64(r.minX) - 0.5, offY + float64(r.minY) - 0.5},
+			FPoint{offX + float64(r.maxX) + 0.5, offY + float64(r.maxY) + 0.5}
+	}
+
+	// touching - where two boxes meet, ok is false when they overlap
+	touching := func(aMin, aMax, bMin, bMax FPoint) (edge [2]FPoint, ok bool) {
+		edge = [2]FPoint{
+			{max(aMin.X, bMin.X), max(aMin.Y, bMin.Y)},
+			{min(aMax.X, bMax.X), min(aMax.Y, bMax.Y)},
+		}
+
+		return edge, edge[0].X == edge[1].X || edge[0].Y == edge[1].Y
+	}
+
+	portals := make([]Portal, len(rects))
+
+	for i, r := range rects {
+		portals[i].Min, portals[i].Max = corners(r)
+	}
+
+	portals[0].Entry = centre(first)
+	portals[len(portals)-1].Exit = centre(path[len(path)-1])
+
+	for i := 1; i < len(portals); i++ {
+		a, b := portals[i-1], portals[i]
+
+		edge, ok := touching(a.Min, a.Max, b.Min, b.Max)
+		if !ok {
+			// The rectangles overlap, cross where the path leaves the first one
+			cellMin, cellMax := corners(rect{minX: rects[i].first.Col, minY: rects[i].first.Row,
+				maxX: rects[i].first.Col, maxY: rects[i].first.Row})
+			edge, _ = touching(a.Min, a.Max, cellMin, cellMax)
+		}
+
+		portals[i-1].Exit = edge
+		portals[i].Entry = edge
+	}
+
+	return portals
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestPathToPortalsCorridor(t *testing.T) {
+	grid := parseGrid(
+		"######",
+		"......",
+		"######",
+	)
+
+	path, err := FindPath(grid, Point{0, 1}, Point{5, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	portals := PathToPortals(grid, path)
+	if len(portals) != 1 {
+		t.Fatalf("got %d rectangles, want the corridor as one", len(portals))
+	}
+
+	p := portals[0]
+	if p.Min != (FPoint{-0.5, 0.5}) || p.Max != (FPoint{5.5, 1.5}) {
+		t.Fatalf("rectangle runs from %v to %v, want the whole corridor", p.Min, p.Max)
+	}
+	if p.Entry != [2]FPoint{{0, 1}, {0, 1}} || p.Exit != [2]FPoint{{5, 1}, {5, 1}} {
+		t.Fatalf("entered at %v and left at %v, want the start and target centres", p.Entry, p.Exit)
+	}
+}
+
+func TestPathToPortalsCorner(t *testing.T) {
+	grid := parseGrid(
+		"...",
+		"##.",
+		"##.",
+	)
+	path := []*Cell{grid[0][0], grid[0][1], grid[0][2], grid[1][2], grid[2][2]}
+
+	portals := PathToPortals(grid, path)
+	if len(portals) != 2 {
+		t.Fatalf("got %d rectangles, want the two legs of the corner", len(portals))
+	}
+	if portals[0].Exit != portals[1].Entry {
+		t.Fatalf("first leg left by %v, second entered by %v", portals[0].Exit, portals[1].Entry)
+	}
+	if want := [2]FPoint{{1.5, 0.5}, {2.5, 0.5}}; portals[0].Exit != want {
+		t.Fatalf("portal between the legs is %v, want %v", portals[0].Exit, want)
+	}
+}
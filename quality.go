@@ -0,0 +1,24 @@
+package main
+
+// TestHeuristicQuality - expansions and path cost of a search from the top left
+// to the bottom right walkable cell of grid with heuristic h and movement moves,
+// for comparing heuristics against each other. Cost is -1 when there is no path.
+func TestHeuristicQuality(grid Grid, h Heuristic, moves MovementMode) (expansions int, cost int) {
+	if len(grid) == 0 {
+		return 0, -1
+	}
+
+	first, ok := NearestWalkable(grid, Point{0, 0})
+	if !ok {
+		return 0, -1
+	}
+
+	last, _ := NearestWalkable(grid, Point{len(grid[len(grid)-1]) - 1, len(grid) - 1})
+
+	s := NewSolver(grid, WithMovement(moves), WithHeuristic(h))
+	if _, err := s.FindPath(first.Index(), last.Index()); err != nil {
+		return s.Stats.Expansions, -1
+	}
+
+	return s.Stats.Expansions, s.Stats.Cost
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestHeuristicQualityOctileBeatsManhattan(t *testing.T) {
+	grid := parseGrid(
+		".#.....",
+		".......",
+		"..#.#..",
+		"...#...",
+		"...##..",
+		"...#...",
+	)
+
+	octileExpansions, octileCost := TestHeuristicQuality(grid, Octile, DIAGONAL)
+	manhattanExpansions, manhattanCost := TestHeuristicQuality(grid, Manhattan, DIAGONAL)
+
+	if want, ok := bruteForceShortest(grid, Point{0, 0}, Point{6, 5}); !ok || octileCost != want || manhattanCost != want {
+		t.Fatalf("Octile costs %d, Manhattan %d, want %d", octileCost, manhattanCost, want)
+	}
+
+	if octileExpansions >= manhattanExpansions {
+		t.Fatalf("Octile expanded %d cells, Manhattan %d", octileExpansions, manhattanExpansions)
+	}
+}
+
+func TestHeuristicQualityIndependentKnobs(t *testing.T) {
+	grid := NewGrid(6, 6)
+
+	// Octile never overestimates cardinal moves, it's just less informed
+	_, cardinalOctile := TestHeuristicQuality(grid, Octile, CARDINAL)
+	_, cardinalManhattan := TestHeuristicQuality(grid, Manhattan, CARDINAL)
+
+	if cardinalOctile != 100 || cardinalManhattan != 100 {
+		t.Fatalf("cardinal costs %d with Octile and %d with Manhattan, want 100", cardinalOctile, cardinalManhattan)
+	}
+
+	if _, cost := TestHeuristicQuality(parseGrid(".#", "#."), Octile, CARDINAL); cost != -1 {
+		t.Fatalf("cost %d through a corner with cardinal moves, want -1", cost)
+	}
+}
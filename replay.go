@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// replayCase - what DumpCase writes, the grid as MarshalRLE and the path as
+// grid indices
+type replayCase struct {
+	Width  int
+	Height int
+	Grid   string
+	Start  Point
+	Target Point
+	Path   []Point
+}
+
+// DumpCase - grid, endpoints and expected path as JSON, so a reported problem can
+// be saved and replayed with LoadCase. Only walls of the grid are kept.
+func DumpCase(grid Grid, start Point, target Point, path []*Cell) ([]byte, error) {
+	c := replayCase{
+		Height: len(grid),
+		Grid:   grid.MarshalRLE(),
+		Start:  start,
+		Target: target,
+		Path:   PathPoints(path),
+	}
+
+	if len(grid) > 0 {
+		c.Width = len(grid[0])
+	}
+
+	return json.Marshal(c)
+}
+
+// LoadCase - grid, endpoints and expected path saved by DumpCase, the path made
+// of cells of the new grid
+func LoadCase(data []byte) (Grid, Point, Point, []*Cell, error) {
+	var c replayCase
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, Point{}, Point{}, nil, err
+	}
+
+	grid, err := ParseRLE(c.Grid, c.Width, c.Height)
+	if err != nil {
+		return nil, Point{}, Point{}, nil, err
+	}
+
+	path := make([]*Cell, len(c.Path))
+	for i, p := range c.Path {
+		if !grid.InBounds(p.X, p.Y) {
+			return nil, Point{}, Point{}, nil, fmt.Errorf("path cell %v: %w", p, ErrOutOfBounds)
+		}
+
+		path[i] = grid[p.Y][p.X]
+	}
+
+	return grid, c.Start, c.Target, path, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDumpCaseReplays(t *testing.T) {
+	grid := parseGrid(
+		"......",
+		".####.",
+		"....#.",
+		"##....",
+	)
+	start, target := Point{0, 2}, Point{5, 0}
+
+	path, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := DumpCase(grid, start, target, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, loadedStart, loadedTarget, want, err := LoadCase(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadedStart != start || loadedTarget != target {
+		t.Fatalf("loaded endpoints %v, %v, want %v, %v", loadedStart, loadedTarget, start, target)
+	}
+	if loaded.MarshalRLE() != grid.MarshalRLE() {
+		t.Fatalf("loaded grid %q, want %q", loaded.MarshalRLE(), grid.MarshalRLE())
+	}
+
+	replayed, err := FindPath(loaded, loadedStart, loadedTarget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(PathPoints(replayed), PathPoints(want)) {
+		t.Fatalf("replay found %v, stored path is %v", PathPoints(replayed), PathPoints(want))
+	}
+	if want[0] != loaded[start.Y][start.X] {
+		t.Fatal("stored path isn't made of the loaded grid's cells")
+	}
+}
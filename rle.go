@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseRLE - grid from a run-length encoded string such as "5.3#2.": a count
+// (1 when left out) followed by '.' for open cells or '#' for walls, filling
+// rows left to right starting with row 0
+func ParseRLE(s string, width int, height int) (Grid, error) {
+	if width < 0 || height < 0 {
+		return nil, fmt.Errorf("bad grid size %dx%d", width, height)
+	}
+
+	grid := NewGrid(width, height)
+	total := width * height
+	filled := 0
+
+	for i := 0; i < len(s); {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+
+		count := 1
+		if i > start {
+			var err error
+			if count, err = strconv.Atoi(s[start:i]); err != nil {
+				return nil, fmt.Errorf("bad run length %q: %v", s[start:i], err)
+			}
+		}
+
+		if i == len(s) {
+			return nil, fmt.Errorf("run length %d at the end isn't followed by a cell", count)
+		}
+
+		var state CellState
+		switch s[i] {
+		case '.':
+			state = UNSEEN
+		case '#':
+			state = DISABLED
+		default:
+			return nil, fmt.Errorf("unknown cell %q at offset %d", s[i], i)
+		}
+		i++
+
+		if filled+count > total {
+			return nil, fmt.Errorf("decodes to more than %dx%d = %d cells", width, height, total)
+		}
+
+		for ; count > 0; count-- {
+			grid[filled/width][filled%width].State = state
+			filled++
+		}
+	}
+
+	if filled != total {
+		return nil, fmt.Errorf("decodes to %d cells, %dx%d needs %d", filled, width, height, total)
+	}
+
+	return grid, nil
+}
+
+// MarshalRLE - walls of the grid in the format read by ParseRLE
+func (g Grid) MarshalRLE() string {
+	var b strings.Builder
+
+	count := 0
+	var last byte
+
+	flush := func() {
+		if count > 1 {
+			b.WriteString(strconv.Itoa(count))
+		}
+		if count > 0 {
+			b.WriteByte(last)
+		}
+	}
+
+	for y := range g {
+		for x := range g[y] {
+			c := byte('.')
+			if g[y][x].State == DISABLED {
+				c = '#'
+			}
+
+			if count > 0 && c != last {
+				flush()
+				count = 0
+			}
+
+			last = c
+			count++
+		}
+	}
+
+	flush()
+
+	return b.String()
+}
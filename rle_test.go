@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRLERoundTrip(t *testing.T) {
+	grid := parseGrid(
+		".....###",
+		"#.......",
+		"##..#..#",
+	)
+
+	encoded := grid.MarshalRLE()
+	if encoded != "5.4#7.2#2.#2.#" {
+		t.Fatalf("encoded to %q", encoded)
+	}
+
+	decoded, err := ParseRLE(encoded, 8, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for y := range grid {
+		for x := range grid[y] {
+			if decoded[y][x].Walkable() != grid[y][x].Walkable() {
+				t.Fatalf("cell %d, %d walkable %v, was %v", x, y, decoded[y][x].Walkable(), grid[y][x].Walkable())
+			}
+		}
+	}
+}
+
+func TestParseRLEErrors(t *testing.T) {
+	cases := []struct {
+		name          string
+		rle           string
+		width, height int
+	}{
+		{"too short", "5.", 3, 2},
+		{"too long", "7.", 3, 2},
+		{"unknown cell", "3.3x", 3, 2},
+		{"dangling count", "6", 3, 2},
+		{"negative width", "", -1, 2},
+		{"negative height", "", 2, -3},
+	}
+
+	for _, c := range cases {
+		if _, err := ParseRLE(c.rle, c.width, c.height); err == nil {
+			t.Errorf("%s: %q parsed as %dx%d", c.name, c.rle, c.width, c.height)
+		}
+	}
+}
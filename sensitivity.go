@@ -0,0 +1,31 @@
+package main
+
+// CostSensitivity - cost of the cheapest path from start to target with the
+// Weight of cell set to each of weights in turn, -1 where there's no path. The
+// grid itself is left untouched. Weights below 0 are taken as 0, as a cell that
+// paid back part of the move could make the heuristic overestimate.
+func CostSensitivity(grid Grid, start Point, target Point, cell Point, weights []int) []int {
+	s := NewSolver(grid)
+	w := s.NewWorkspace()
+
+	current := 0
+	if grid.InBounds(cell.X, cell.Y) {
+		current = grid[cell.Y][cell.X].Weight
+	}
+
+	costs := make([]int, len(weights))
+
+	for i, weight := range weights {
+		// Lowering the weight takes the surcharge below 0, which is fine as long
+		// as the weight it stands for isn't
+		s.surcharge = map[Point]int{cell: max(weight, 0) - current}
+
+		if _, err := w.Solve(start, target); err != nil {
+			costs[i] = -1
+		} else {
+			costs[i] = s.Stats.Cost
+		}
+	}
+
+	return costs
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCostSensitivityPlateaus(t *testing.T) {
+	// A gap in the wall at (3, 1) on the straight way, another further down
+	grid := parseGrid(
+		"...#...",
+		".......",
+		"...#...",
+		"...#...",
+		".......",
+	)
+
+	costs := CostSensitivity(grid, Point{0, 1}, Point{6, 1}, Point{3, 1}, []int{0, 10, 20, 30, 40, 50})
+
+	// Past 24 the six diagonals through the lower gap are cheaper
+	if want := []int{60, 70, 80, 84, 84, 84}; !reflect.DeepEqual(costs, want) {
+		t.Fatalf("got %v, want %v", costs, want)
+	}
+	if grid[1][3].Weight != 0 {
+		t.Fatal("CostSensitivity changed the grid")
+	}
+}
+
+func TestCostSensitivityLowered(t *testing.T) {
+	grid := parseGrid(
+		"...#...",
+		".......",
+		"...#...",
+		"...#...",
+		".......",
+	)
+	grid[1][3].Weight = 50
+
+	costs := CostSensitivity(grid, Point{0, 1}, Point{6, 1}, Point{3, 1}, []int{50, 20, 0, -20})
+
+	// Below 0 counts as 0, the path can't cost less than its moves
+	if want := []int{84, 80, 60, 60}; !reflect.DeepEqual(costs, want) {
+		t.Fatalf("got %v, want %v", costs, want)
+	}
+	if grid[1][3].Weight != 50 {
+		t.Fatal("CostSensitivity changed the grid")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+// CostFunc - cost of the move between two neighbouring cells
+type CostFunc func(from *Cell, to *Cell) int
+
+// SmoothPathWeighted - path cut down to the cells where it turns, skipping
+// ahead along straight lines that don't cost more than the stretch of path they
+// replace, so it doesn't cut across expensive ground. Lines are priced cell by
+// cell along their Bresenham line with cost, nil meaning 10 or 14 per move plus
+// the Weight of the cell entered. Consecutive cells of the result have line of
+// sight, but aren't necessarily neighbours.
+func SmoothPathWeighted(grid Grid, path []*Cell, cost CostFunc) []*Cell {
+	if len(path) < 3 {
+		return path
+	}
+
+	if cost == nil {
+		cost = func(from *Cell, to *Cell) int {
+			if isDiagonal(from, to) {
+				return 14 + to.Weight
+			}
+
+			return 10 + to.Weight
+		}
+	}
+
+	// lineCost - cost of the straight line between two cells, false if it's blocked
+	lineCost := func(from *Cell, to *Cell) (int, bool) {
+		total := 0
+		prev := from
+
+		for _, p := range BresenhamLine(from.Col, from.Row, to.Col, to.Row)[1:] {
+			if !grid.InBounds(p.X, p.Y) || !grid[p.Y][p.X].Walkable() || edgeWall(prev, grid[p.Y][p.X]) {
+				return 0, false
+			}
+
+			total += cost(prev, grid[p.Y][p.X])
+			prev = grid[p.Y][p.X]
+		}
+
+		return total, true
+	}
+
+	smoothed := []*Cell{path[0]}
+
+	for i := 0; i < len(path)-1; {
+		// Furthest cell the line from path[i] reaches without costing more
+		next := i + 1
+		along := cost(path[i], path[i+1])
+
+		for j := i + 2; j < len(path); j++ {
+			along += cost(path[j-1], path[j])
+
+			if straight, ok := lineCost(path[i], path[j]); ok && straight <= along {
+				next = j
+			}
+		}
+
+		smoothed = append(smoothed, path[next])
+		i = next
+	}
+
+	return smoothed
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSmoothPathWeightedKeepsDetour(t *testing.T) {
+	grid := NewGrid(7, 3)
+	for x := 2; x <= 4; x++ {
+		grid[1][x].Weight = 50
+	}
+
+	path, err := FindPath(grid, Point{0, 1}, Point{6, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsPoint(path, Point{3, 1}) {
+		t.Fatalf("path %v wades through the swamp", PathPoints(path))
+	}
+
+	// Priced without the Weight, the straight line through the swamp looks best
+	plain := func(from *Cell, to *Cell) int {
+		return moveCost([]*Cell{from, to})
+	}
+	if naive := SmoothPathWeighted(grid, path, plain); len(naive) != 2 {
+		t.Fatalf("smoothing by distance alone gave %v, want start to target", PathPoints(naive))
+	}
+
+	smoothed := SmoothPathWeighted(grid, path, nil)
+	for i := 1; i < len(smoothed); i++ {
+		from, to := smoothed[i-1], smoothed[i]
+
+		for _, p := range BresenhamLine(from.Col, from.Row, to.Col, to.Row) {
+			if grid[p.Y][p.X].Weight > 0 {
+				t.Fatalf("smoothed path %v cuts through the swamp at %v", PathPoints(smoothed), p)
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+// NearestWalkable - the non-wall cell closest to p in straight-line distance,
+// false if the grid has none. p may be outside the grid.
+func NearestWalkable(grid Grid, p Point) (*Cell, bool) {
+	var nearest *Cell
+	nearestDist := 0
+
+	for y := range grid {
+		for x := range grid[y] {
+			if grid[y][x].State == DISABLED {
+				continue
+			}
+
+			dist := (x-p.X)*(x-p.X) + (y-p.Y)*(y-p.Y)
+
+			if nearest == nil || dist < nearestDist {
+				nearest = grid[y][x]
+				nearestDist = dist
+			}
+		}
+	}
+
+	return nearest, nearest != nil
+}
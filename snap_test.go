@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSnapToWalkableTarget(t *testing.T) {
+	grid := parseGrid(
+		"....#",
+		"....#",
+		"....#",
+	)
+
+	if _, err := FindPath(grid, Point{0, 1}, Point{4, 1}); err == nil {
+		t.Fatal("found a path to a wall without snapping")
+	}
+
+	s := NewSolver(grid)
+	s.SnapToWalkable = true
+
+	path, err := s.FindPath(Point{0, 1}, Point{4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last := path[len(path)-1].Index(); last != (Point{3, 1}) {
+		t.Fatalf("path ends at %v, want the open cell next to the wall at (3, 1)", last)
+	}
+}
+
+func TestNearestWalkable(t *testing.T) {
+	grid := parseGrid(
+		"###",
+		"##.",
+	)
+
+	cell, ok := NearestWalkable(grid, Point{-3, 0})
+	if !ok || cell.Index() != (Point{2, 1}) {
+		t.Fatalf("got %v, %v, want the only open cell (2, 1)", cell, ok)
+	}
+
+	if _, ok := NearestWalkable(parseGrid("##"), Point{0, 0}); ok {
+		t.Fatal("found a walkable cell in a grid of walls")
+	}
+}
@@ -0,0 +1,513 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultCongestionCost - extra cost per occupant of a cell, roughly one straight step
+const DefaultCongestionCost = 10
+
+// NoClimbLimit - MaxClimb that lets a move go up any height
+const NoClimbLimit = -1
+
+// Solver - grid plus the settings used by every search run on it
+type Solver struct {
+	Grid Grid
+
+	// Movement - which neighbours a cell has, DIAGONAL by default
+	Movement MovementMode
+
+	// StraightCost, DiagonalCost - cost of a move to a side or corner neighbour,
+	// 10 and 14 by default. The built-in heuristics assume the defaults.
+	StraightCost int
+	DiagonalCost int
+
+	// Heuristic - estimate of the remaining cost. Unless set through WithHeuristic
+	// it follows Movement: Octile for DIAGONAL, Manhattan for CARDINAL.
+	Heuristic Heuristic
+
+	// NewOpenSet - builds the open list of each search, NewHeapOpenSet by default
+	NewOpenSet func() OpenSet
+
+	// Warnings - problems with the settings found by NewSolver
+	Warnings []string
+
+	// Congestion - number of occupants per cell, kept up to date by the caller.
+	// Entering a cell costs CongestionCost more per occupant, and a cell whose
+	// occupancy reached its Capacity can't be entered at all.
+	Congestion     map[Point]int
+	CongestionCost int
+
+	// CellCost - optional multiplier of the cost of moving into the cell at
+	// column x, row y, asked for as the search goes, so costs can live outside
+	// the grid. Should be at least 1, lower values let the heuristic overestimate.
+	CellCost func(x int, y int) int
+
+	// Neighbours - optional stand-in for GetNeighbourCells, e.g. for maps that
+	// wrap round at the edges. Its costs are used as they are, without Movement,
+	// StraightCost or DiagonalCost, and edge walls still apply. The search needs
+	// it to be symmetric (see CheckNeighbourSymmetry) and Heuristic to stay a
+	// lower bound under it.
+	Neighbours NeighbourFunc
+
+	// NeighbourFilter - optional last say on every move, returning false drops
+	// the move from "from" to "to". Called during the search, so it can follow
+	// rules that change between searches.
+	NeighbourFilter func(from *Cell, to *Cell) bool
+
+	// SnapToWalkable - move a start or target that's on a wall or off the grid
+	// to the nearest walkable cell instead of failing
+	SnapToWalkable bool
+
+	// CellWidth, CellHeight - real size of a cell, for tilemaps whose cells aren't
+	// square. Scales the cost of moving along each axis, diagonals included, and
+	// the heuristic along each axis. 0 counts as 1.
+	CellWidth  float64
+	CellHeight float64
+
+	// BacktrackPenalty - extra cost of a move that takes the heuristic further
+	// from the target, so paths wander less without ruling out detours
+	BacktrackPenalty int
+
+	// Fatigue - makes later moves dearer, each move costs 1 + Fatigue*moves
+	// made so far times its normal cost. The heuristic keeps using the normal
+	// cost, so it stays a lower bound. Moves made are counted along the route
+	// each cell was reached by.
+	Fatigue float64
+
+	// LazyHeuristic - put off calling Heuristic for a cell until it comes off the
+	// open list, using a bound taken from its parent until then. Saves calls to
+	// expensive heuristics for cells that are never expanded. Needs a consistent
+	// heuristic to keep paths shortest.
+	LazyHeuristic bool
+
+	// GoodEnoughH - when above 0, stop at the first expanded cell whose heuristic
+	// is at most this and return the path to it. That cell may not be the target,
+	// and the path to it isn't necessarily the cheapest way to get that close.
+	GoodEnoughH int
+
+	// HeuristicWeight - when above 1, order the open list by G + HeuristicWeight*H
+	// (weighted A*). Expands fewer cells, but the path may cost up to that many
+	// times the shortest one.
+	HeuristicWeight float64
+
+	// MaxSteps - when above 0, most cells a search may expand before giving up
+	// with ErrBudget
+	MaxSteps int
+
+	// AdaptiveWeight - when above 1 along with MaxSteps, inflate the heuristic as
+	// the budget runs down, from 1 at the start up to AdaptiveWeight once it's
+	// used up, so the search pushes harder for the target the less it has left.
+	// Finds paths a plain search runs out of budget for, which may not be the
+	// shortest. Cells keep their place on the open list from when they were added.
+	AdaptiveWeight float64
+
+	// MaxReopens - when above 0, most times a cell already expanded may go back
+	// on the open list after a cheaper way to it turns up, which only happens
+	// with an inconsistent heuristic. Bounds the work on bad inputs at the risk
+	// of a path that isn't the shortest, Stats.Warnings says when it kicked in.
+	MaxReopens int
+
+	// Greedy - order the open list by the heuristic alone, ignoring the cost so
+	// far. Heads straight for the target and usually expands far fewer cells than
+	// A*, but the path can be a lot longer than the shortest one, so only for
+	// when any path will do.
+	Greedy bool
+
+	// FirstPath - stop as soon as the target is reached from an expanded cell,
+	// without waiting for it to come off the open list. Saves a little more work
+	// on top of Greedy, at the same price: the path may not be the shortest.
+	FirstPath bool
+
+	// PriorityBias - optional nudge added to F when ordering the open list, the
+	// costs themselves are left alone. Steers which cells get expanded first, but
+	// anything other than 0 may cost the path its optimality.
+	PriorityBias func(cell *Cell) int
+
+	// PreferDiagonals - of the cheapest paths, take one with the most diagonal
+	// moves, for routes that look more diagonal. Priorities are left as they are,
+	// the open set breaks their ties, so it has to be a TieBreaker.
+	PreferDiagonals bool
+
+	// SortNeighbours - push the neighbours of each expanded cell onto the open
+	// list cheapest F first, judged by the base move cost and the heuristic, and
+	// the closest to the target first among equal F. Open lists that break ties
+	// by push order then try the most promising cell first, which helps an early
+	// stop such as GoodEnoughH come sooner. Costs a small sort and extra
+	// heuristic calls per expansion.
+	SortNeighbours bool
+
+	// MaxDiagonalRun - when above 0, most diagonal moves allowed in a row before a
+	// straight one, for a stepped look. Each cell only remembers the run of the
+	// route it was reached by, so this shapes the path rather than finding the
+	// cheapest path under the limit.
+	MaxDiagonalRun int
+
+	// TurnCostFunc - optional extra cost of changing heading, given the heading
+	// into the current cell and the heading of the next move. Like MaxDiagonalRun
+	// it works from the route each cell was reached by.
+	TurnCostFunc func(fromDir Direction, toDir Direction) int
+
+	// TurnWeight - when above 0, cost added for every change of heading, see
+	// WithTurnWeight
+	TurnWeight int
+
+	// DirectionCost - added to the cost of every move heading that way, e.g. a
+	// negative EAST for a tailwind. The heuristic is scaled down by the cheapest
+	// entry so it stays a lower bound.
+	DirectionCost map[Direction]int
+
+	// PreferredTag, TagDiscount - entering a cell whose Tag is PreferredTag costs
+	// TagDiscount less, never below 0, e.g. to keep to roads. The heuristic is
+	// scaled down to match. Set through PreferTag, PreferredTag can't be NoTag.
+	PreferredTag int
+	TagDiscount  int
+
+	// Exposed - optional check for cover, true when entering cell from the side
+	// fromDir (EAST for a move heading west) leaves the mover exposed. Such a
+	// move costs ExposedCost more, or can't be made at all when ExposedCost is 0.
+	Exposed     func(cell *Cell, fromDir Direction) bool
+	ExposedCost int
+
+	// RequireLOSFromStart - only move through cells the start has LineOfSight to,
+	// the rest count as walls
+	RequireLOSFromStart bool
+
+	// MaxClimb - highest a move may go up in Elevation, NoClimbLimit by default.
+	// Going down is always allowed, so with 0 a cliff can be dropped off but not
+	// climbed back up.
+	MaxClimb int
+
+	// CornerCost - extra cost of a diagonal move for each of the two cells it
+	// squeezes past (the ones beside both ends) that is a wall
+	CornerCost int
+
+	// MaxFrames - most frames RecordFrames returns, 0 for no limit
+	MaxFrames int
+
+	// Stats - numbers from the last search
+	Stats Stats
+
+	cache *pathCache
+
+	// surcharge - extra cost of entering a cell, set by searches built on Solver
+	surcharge map[Point]int
+
+	// goal - cells besides the target the search may stop at, the heuristic has
+	// to be a lower bound on the cost of reaching any of them. The target then
+	// only steers the heuristic and may be a wall.
+	goal func(cell *Cell) bool
+
+	// landmarks - tables of the ALT heuristic, see WithLandmarkHeuristic
+	landmarks *Landmarks
+
+	useComponents bool
+	components    *Components
+
+	agentRadius int
+	clearance   [][]int
+}
+
+// Stats - what a search did
+type Stats struct {
+	// Expansions - cells taken off the open list
+	Expansions int
+
+	// Cost - cost of the path found, 0 without one
+	Cost int
+
+	// MaxDepth - most moves from the start of any expanded cell, along the route
+	// it was reached by. Close to the number of cells expanded when the search
+	// snakes through a maze.
+	MaxDepth int
+
+	// Optimal - whether the path found is sure to be the cheapest. False whenever
+	// the settings can't guarantee it, e.g. with a custom heuristic, even if the
+	// path happens to be the cheapest anyway.
+	Optimal bool
+
+	// Elapsed - time from setting up the search to it finishing
+	Elapsed time.Duration
+
+	// Warnings - things that went wrong during the search without stopping it
+	Warnings []string
+}
+
+// Option - setting applied by NewSolver before it checks the configuration
+type Option func(*Solver)
+
+func NewSolver(grid Grid, opts ...Option) *Solver {
+	s := &Solver{
+		Grid:           grid,
+		StraightCost:   10,
+		DiagonalCost:   14,
+		Congestion:     make(map[Point]int),
+		CongestionCost: DefaultCongestionCost,
+		CellWidth:      1,
+		CellHeight:     1,
+		MaxFrames:      DefaultMaxFrames,
+		MaxClimb:       NoClimbLimit,
+		NewOpenSet:     NewHeapOpenSet,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.landmarks != nil && s.Heuristic == nil {
+		// The distances depend on the costs and movement rules, which are only
+		// settled now
+		s.landmarks = s.landmarks.measure(s)
+		s.Heuristic = s.landmarks.Heuristic
+	} else {
+		// A later option set another heuristic
+		s.landmarks = nil
+	}
+
+	if s.Heuristic == nil {
+		s.Heuristic = Octile
+		if s.Movement == CARDINAL {
+			s.Heuristic = Manhattan
+		}
+	}
+
+	s.Warnings = s.Validate()
+
+	return s
+}
+
+// FindPath - shortest path from start to target using the default settings
+func FindPath(grid Grid, start Point, target Point) ([]*Cell, error) {
+	return NewSolver(grid).FindPath(start, target)
+}
+
+// FindPath - shortest path from start to target, both ends included. Its cost
+// ends up in Stats.Cost.
+func (s *Solver) FindPath(start Point, target Point) ([]*Cell, error) {
+	return s.solve(&Stepper{solver: s}, start, target)
+}
+
+// solve - FindPath running the search on st
+func (s *Solver) solve(st *Stepper, start Point, target Point) ([]*Cell, error) {
+	s.Stats = Stats{}
+
+	if s.cache != nil {
+		s.cache.sync(s)
+
+		if entry, ok := s.cache.get(Query{start, target}); ok {
+			s.Stats.Cost = entry.cost
+			s.Stats.Optimal = entry.optimal
+			return entry.path, nil
+		}
+	}
+
+	var path []*Cell
+	var err error
+
+	if s.TurnWeight > 0 {
+		// One node per cell can't keep routes arriving with different headings apart
+		path, err = s.findPathHeadings(start, target)
+	} else {
+		st.reset(start, target)
+		for !st.Step() {
+		}
+
+		path, err = st.Result()
+	}
+
+	if err == nil && s.cache != nil {
+		s.cache.add(Query{start, target}, path, s.Stats)
+	}
+
+	return path, err
+}
+
+func (s *Solver) cellSize() (float64, float64) {
+	w, h := s.CellWidth, s.CellHeight
+	if w == 0 {
+		w = 1
+	}
+	if h == 0 {
+		h = 1
+	}
+
+	return w, h
+}
+
+// heuristic - Heuristic from one point to another. When cells aren't square it's
+// taken along each axis on its own and scaled by the cell size, less what the
+// diagonal moves save over a straight step along each axis, priced as stepCost
+// prices them, unless it comes from landmarks measured with the cell size
+// already. Scaled down when DirectionCost or TagDiscount make some moves
+// cheaper.
+func (s *Solver) heuristic(from Point, to Point) int {
+	w, h := s.cellSize()
+
+	var estimate float64
+	if (w == 1 && h == 1) || s.landmarks != nil {
+		estimate = float64(s.Heuristic(from, to))
+	} else {
+		// Scaled as stepCost scales, rounding included
+		scaleX, scaleY := w, h
+		if s.StraightCost > 0 {
+			scaleX = float64(s.stepCost(&Cell{}, &Cell{Col: 1}, s.StraightCost)) / float64(s.StraightCost)
+			scaleY = float64(s.stepCost(&Cell{}, &Cell{Row: 1}, s.StraightCost)) / float64(s.StraightCost)
+		}
+
+		alongX := scaleX * float64(s.Heuristic(from, Point{to.X, from.Y}))
+		alongY := scaleY * float64(s.Heuristic(from, Point{from.X, to.Y}))
+		estimate = alongX + alongY
+
+		dx, dy := abs(to.X-from.X), abs(to.Y-from.Y)
+		if diagonals := min(dx, dy); diagonals > 0 && s.Movement != CARDINAL {
+			// Each diagonal stands in for a column and a row
+			diagonal := float64(s.stepCost(&Cell{}, &Cell{Row: 1, Col: 1}, s.DiagonalCost))
+			saving := alongX/float64(dx) + alongY/float64(dy) - diagonal
+
+			estimate -= float64(diagonals) * max(saving, 0)
+		}
+	}
+
+	// Every move costs at least this share of its normal cost
+	floor := s.StraightCost + s.cheapestDirectionCost() - s.tagDiscount()
+	if floor < s.StraightCost && s.StraightCost > 0 {
+		estimate *= float64(max(floor, 0)) / float64(s.StraightCost)
+	}
+
+	return int(math.Round(estimate))
+}
+
+// stepCost - base cost of the move between two neighbours scaled by the cell size
+func (s *Solver) stepCost(from *Cell, to *Cell, base int) int {
+	w, h := s.cellSize()
+	if w == 1 && h == 1 {
+		return base
+	}
+
+	var scale float64
+
+	switch {
+	case from.Col != to.Col && from.Row != to.Row:
+		scale = math.Hypot(w, h) / math.Sqrt2
+	case from.Col != to.Col:
+		scale = w
+	default:
+		scale = h
+	}
+
+	return int(math.Round(float64(base) * scale))
+}
+
+// cellCost - base cost of a move into cell times CellCost
+func (s *Solver) cellCost(cell *Cell, cost int) int {
+	if s.CellCost == nil {
+		return cost
+	}
+
+	return cost * s.CellCost(cell.Col, cell.Row)
+}
+
+// turnCost - TurnCostFunc for the move from a node to the next cell, 0 at the start
+func (s *Solver) turnCost(from *node, to *Cell) int {
+	if s.TurnCostFunc == nil || from.Heading == NONE {
+		return 0
+	}
+
+	return s.TurnCostFunc(from.Heading, directionOf(from.Cell, to))
+}
+
+// fatigued - cost of a move out of n after Fatigue is applied
+func (s *Solver) fatigued(n *node, cost int) int {
+	if s.Fatigue == 0 {
+		return cost
+	}
+
+	return int(math.Round(float64(cost) * (1 + s.Fatigue*float64(n.Depth))))
+}
+
+// directionCost - DirectionCost of the move from one cell to the next
+func (s *Solver) directionCost(from *Cell, to *Cell) int {
+	if len(s.DirectionCost) == 0 {
+		return 0
+	}
+
+	return s.DirectionCost[directionOf(from, to)]
+}
+
+// cheapestDirectionCost - lowest DirectionCost of any move, directions left out
+// count as 0
+func (s *Solver) cheapestDirectionCost() int {
+	if len(s.DirectionCost) == 0 {
+		return 0
+	}
+
+	cheapest := s.DirectionCost[NORTH]
+	for dir := NORTHEAST; dir <= NORTHWEST; dir++ {
+		cheapest = min(cheapest, s.DirectionCost[dir])
+	}
+
+	return cheapest
+}
+
+// cornerCost - CornerCost for every wall the move from one cell to the next
+// cuts past, 0 for straight moves
+func (s *Solver) cornerCost(from *Cell, to *Cell) int {
+	if s.CornerCost == 0 || !isDiagonal(from, to) {
+		return 0
+	}
+
+	walls := 0
+	if s.Grid[from.Row][to.Col].State == DISABLED {
+		walls++
+	}
+	if s.Grid[to.Row][from.Col].State == DISABLED {
+		walls++
+	}
+
+	return walls * s.CornerCost
+}
+
+// exposedCost - extra cost of entering to from a neighbour under Exposed, false
+// if the move isn't allowed
+func (s *Solver) exposedCost(from *Cell, to *Cell) (int, bool) {
+	if s.Exposed == nil || !s.Exposed(to, directionOf(to, from)) {
+		return 0, true
+	}
+
+	return s.ExposedCost, s.ExposedCost > 0
+}
+
+// canClimb - whether MaxClimb allows the move from one cell to the next
+func (s *Solver) canClimb(from *Cell, to *Cell) bool {
+	return s.MaxClimb < 0 || to.Elevation-from.Elevation <= s.MaxClimb
+}
+
+// congestionCost - extra cost of entering cell, false if it's already full
+func (s *Solver) congestionCost(cell *Cell) (int, bool) {
+	occupancy := s.Congestion[cell.Index()]
+
+	if cell.Capacity > 0 && occupancy >= cell.Capacity {
+		return 0, false
+	}
+
+	return occupancy * s.CongestionCost, true
+}
+
+// buildPath - follows the parents back from the last node, returns start to last
+func buildPath(last *node) []*Cell {
+	// Depth can be out of date after a cell is reopened, so count
+	length := 0
+	for n := last; n != nil; n = n.Parent {
+		length++
+	}
+
+	path := make([]*Cell, length)
+
+	for n := last; n != nil; n = n.Parent {
+		length--
+		path[length] = n.Cell
+	}
+
+	return path
+}
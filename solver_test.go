@@ -0,0 +1,828 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestCongestionAvoidsFullCell(t *testing.T) {
+	grid := NewGrid(5, 3)
+	start, target := Point{0, 1}, Point{4, 1}
+
+	s := NewSolver(grid)
+
+	first, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first agent stands in the middle of its route, which only fits one
+	middle := first[len(first)/2]
+	middle.Capacity = 1
+	s.Congestion[middle.Index()] = 1
+
+	second, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if containsPoint(second, middle.Index()) {
+		t.Fatalf("second path %v goes through full cell %v", PathPoints(second), middle.Index())
+	}
+}
+
+func TestCongestionCostGrowsWithOccupancy(t *testing.T) {
+	grid := NewGrid(5, 3)
+	s := NewSolver(grid)
+
+	if _, err := s.FindPath(Point{0, 1}, Point{4, 1}); err != nil {
+		t.Fatal(err)
+	}
+	free := s.Stats.Cost
+
+	// Crowd the whole middle row so no way round is free either
+	for x := 1; x < 4; x++ {
+		s.Congestion[Point{x, 0}] = 1
+		s.Congestion[Point{x, 1}] = 1
+		s.Congestion[Point{x, 2}] = 1
+	}
+
+	if _, err := s.FindPath(Point{0, 1}, Point{4, 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := free + 3*DefaultCongestionCost; s.Stats.Cost != want {
+		t.Fatalf("cost through crowded cells is %d, want %d", s.Stats.Cost, want)
+	}
+}
+
+func TestNeighbourFilterForcesDetour(t *testing.T) {
+	grid := NewGrid(6, 6)
+	start, target := Point{0, 5}, Point{5, 5}
+
+	s := NewSolver(grid)
+	if _, err := s.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+	direct := s.Stats.Cost
+
+	// Columns 2 and 3 can only be crossed between along the top row
+	s.NeighbourFilter = func(from *Cell, to *Cell) bool {
+		crosses := (from.Col == 2 && to.Col == 3) || (from.Col == 3 && to.Col == 2)
+
+		return !crosses || (from.Row == 0 && to.Row == 0)
+	}
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < len(path); i++ {
+		if !s.NeighbourFilter(path[i-1], path[i]) {
+			t.Fatalf("path %v makes the vetoed move %v to %v", PathPoints(path), path[i-1].Index(), path[i].Index())
+		}
+	}
+
+	if s.Stats.Cost <= direct {
+		t.Fatalf("detour costs %d, no more than the direct %d", s.Stats.Cost, direct)
+	}
+}
+
+func TestTallCellsAvoidVerticalMoves(t *testing.T) {
+	grid := parseGrid(
+		"......",
+		".####.",
+		"..#...",
+		"...#..",
+		"#..#..",
+		"#...#.",
+	)
+	start, target := Point{0, 0}, Point{5, 5}
+
+	verticalMoves := func(path []*Cell) int {
+		moves := 0
+		for i := 1; i < len(path); i++ {
+			if path[i].Row != path[i-1].Row {
+				moves++
+			}
+		}
+
+		return moves
+	}
+
+	square, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver(grid)
+	s.CellHeight = 3
+
+	if warnings := s.Validate(); len(warnings) != 0 {
+		t.Fatalf("tall cells warn %v", warnings)
+	}
+
+	tall, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := bruteForceScaled(grid, start, target, 1, 3); s.Stats.Cost != want {
+		t.Fatalf("path with tall cells %v costs %d, the cheapest %d", PathPoints(tall), s.Stats.Cost, want)
+	}
+
+	if verticalMoves(tall) >= verticalMoves(square) {
+		t.Fatalf("path with tall cells %v moves vertically %d times, with square cells %v %d times",
+			PathPoints(tall), verticalMoves(tall), PathPoints(square), verticalMoves(square))
+	}
+
+	// Straight down one cell costs three straight steps
+	if _, err := s.FindPath(Point{5, 0}, Point{5, 1}); err != nil || s.Stats.Cost != 30 {
+		t.Fatalf("vertical step costs %d (%v), want 30", s.Stats.Cost, err)
+	}
+}
+
+// bruteForceScaled - cheapest cost from start to target with cells w wide and h
+// high, relaxing every move until nothing changes, 0 when there's no way
+func bruteForceScaled(grid Grid, start Point, target Point, w float64, h float64) int {
+	diagonal := int(math.Round(14 * math.Hypot(w, h) / math.Sqrt2))
+	cost := func(dx int, dy int) int {
+		switch {
+		case dx != 0 && dy != 0:
+			return diagonal
+		case dx != 0:
+			return int(math.Round(10 * w))
+		}
+
+		return int(math.Round(10 * h))
+	}
+
+	dist := map[Point]int{start: 0}
+
+	for changed := true; changed; {
+		changed = false
+
+		for p, d := range dist {
+			neighbours, _ := GetNeighbourCells(grid, grid[p.Y][p.X])
+
+			for _, next := range neighbours {
+				newDist := d + cost(next.Col-p.X, next.Row-p.Y)
+				if old, ok := dist[next.Index()]; !ok || newDist < old {
+					dist[next.Index()] = newDist
+					changed = true
+				}
+			}
+		}
+	}
+
+	return dist[target]
+}
+
+func TestNonSquareCellsShortest(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+
+	for i := 0; i < 100; i++ {
+		grid, start, target := randomGrid(rng, 3+rng.Intn(8), 3+rng.Intn(8))
+		w, h := []float64{1, 2, 1.5}[rng.Intn(3)], []float64{1, 2, 3}[rng.Intn(3)]
+
+		s := NewSolver(grid)
+		s.CellWidth, s.CellHeight = w, h
+
+		if warnings := s.Validate(); len(warnings) != 0 {
+			t.Fatalf("%vx%v cells warn %v", w, h, warnings)
+		}
+
+		if _, err := s.FindPath(start, target); err == nil && s.Stats.Cost != bruteForceScaled(grid, start, target, w, h) {
+			t.Fatalf("grid %d, %vx%v cells: cost %d, the cheapest %d", i, w, h,
+				s.Stats.Cost, bruteForceScaled(grid, start, target, w, h))
+		}
+	}
+}
+
+func TestHeuristicMovementWarnings(t *testing.T) {
+	if s := NewSolver(NewGrid(2, 2), WithHeuristic(Manhattan)); len(s.Warnings) == 0 {
+		t.Fatal("no warning for diagonal movement with Manhattan")
+	}
+
+	if s := NewSolver(NewGrid(2, 2), WithHeuristic(Octile)); len(s.Warnings) != 0 {
+		t.Fatalf("warnings %v for diagonal movement with Octile", s.Warnings)
+	}
+
+	if s := NewSolver(NewGrid(2, 2), WithMovement(CARDINAL), WithHeuristic(Octile)); len(s.Warnings) == 0 {
+		t.Fatal("no warning for cardinal movement with Octile")
+	}
+
+	if s := NewSolver(NewGrid(2, 2), WithMovement(CARDINAL)); len(s.Warnings) != 0 || s.Heuristic == nil {
+		t.Fatalf("warnings %v for cardinal movement with its default heuristic", s.Warnings)
+	}
+}
+
+func TestNoPathErrorCountsExpansions(t *testing.T) {
+	grid := NewGrid(20, 20)
+
+	// Wall the target in
+	for y := 14; y <= 16; y++ {
+		for x := 14; x <= 16; x++ {
+			if x != 15 || y != 15 {
+				grid[y][x].State = DISABLED
+			}
+		}
+	}
+
+	_, err := FindPath(grid, Point{0, 0}, Point{15, 15})
+
+	var noPath *NoPathError
+	if !errors.As(err, &noPath) || !errors.Is(err, ErrNoPath) {
+		t.Fatalf("got %v, want a *NoPathError", err)
+	}
+
+	// Every cell outside the box gets expanded before giving up
+	if reachable := 20*20 - 9; noPath.Expansions != reachable {
+		t.Fatalf("error reports %d expansions, want the %d reachable cells", noPath.Expansions, reachable)
+	}
+}
+
+func TestGoodEnoughHStopsShort(t *testing.T) {
+	s := NewSolver(NewGrid(12, 3))
+	s.GoodEnoughH = 30
+	target := Point{11, 1}
+
+	path, err := s.FindPath(Point{0, 1}, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := path[len(path)-1].Index()
+
+	if last == target {
+		t.Fatal("search went all the way to the target")
+	}
+	if h := s.heuristic(last, target); h > s.GoodEnoughH {
+		t.Fatalf("path ends at %v, %d from the target, want within %d", last, h, s.GoodEnoughH)
+	}
+}
+
+func TestMaxDiagonalRun(t *testing.T) {
+	grid := NewGrid(8, 8)
+	start, target := Point{0, 0}, Point{6, 6}
+
+	longestRun := func(path []*Cell) int {
+		longest, run := 0, 0
+		for i := 1; i < len(path); i++ {
+			if isDiagonal(path[i-1], path[i]) {
+				run++
+			} else {
+				run = 0
+			}
+
+			longest = max(longest, run)
+		}
+
+		return longest
+	}
+
+	free, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if longestRun(free) != 6 {
+		t.Fatalf("unlimited path %v isn't one diagonal run", PathPoints(free))
+	}
+
+	s := NewSolver(grid)
+	s.MaxDiagonalRun = 2
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	if path[len(path)-1].Index() != target {
+		t.Fatalf("path %v doesn't reach the target", PathPoints(path))
+	}
+	if run := longestRun(path); run > 2 {
+		t.Fatalf("path %v has %d diagonal moves in a row", PathPoints(path), run)
+	}
+}
+
+func TestCornerCostPrefersOpenDiagonal(t *testing.T) {
+	grid := parseGrid(
+		".#..",
+		".#..",
+		"....",
+		"....",
+	)
+	start, target := Point{1, 3}, Point{0, 1}
+
+	plain, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver(grid)
+	s.CornerCost = 5
+
+	cornered := 0
+	for i := 1; i < len(plain); i++ {
+		cornered += s.cornerCost(plain[i-1], plain[i])
+	}
+	if cornered == 0 {
+		t.Fatalf("plain path %v doesn't cut past the wall", PathPoints(plain))
+	}
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if moveCost(path) != moveCost(plain) {
+		t.Fatalf("path %v costs %d before corners, plain path %d", PathPoints(path), moveCost(path), moveCost(plain))
+	}
+	if s.Stats.Cost != moveCost(path) {
+		t.Fatalf("path %v pays %d for corners", PathPoints(path), s.Stats.Cost-moveCost(path))
+	}
+}
+
+func TestLazyHeuristicFewerCalls(t *testing.T) {
+	grid := maze()
+	start, target := Point{0, 20}, Point{20, 0}
+
+	counted := func(calls *int) Option {
+		return WithHeuristic(func(from Point, to Point) int {
+			*calls++
+			return Octile(from, to)
+		})
+	}
+
+	var eagerCalls, lazyCalls int
+
+	eager := NewSolver(grid, counted(&eagerCalls))
+	if _, err := eager.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+
+	lazy := NewSolver(grid, counted(&lazyCalls))
+	lazy.LazyHeuristic = true
+	if _, err := lazy.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+
+	if lazy.Stats.Cost != eager.Stats.Cost {
+		t.Fatalf("lazy path costs %d, eager %d", lazy.Stats.Cost, eager.Stats.Cost)
+	}
+	if lazyCalls >= eagerCalls {
+		t.Fatalf("lazy search called the heuristic %d times, eager %d", lazyCalls, eagerCalls)
+	}
+}
+
+func TestBacktrackPenaltyLessWandering(t *testing.T) {
+	grid := parseGrid(
+		"....#..",
+		"...##..",
+		"...#...",
+		"...##..",
+		"...#...",
+		".......",
+	)
+	start, target := Point{1, 0}, Point{6, 1}
+
+	// Moves that take the path further from the target
+	away := func(s *Solver, path []*Cell) int {
+		moves := 0
+		for i := 1; i < len(path); i++ {
+			if s.heuristic(path[i].Index(), target) > s.heuristic(path[i-1].Index(), target) {
+				moves++
+			}
+		}
+
+		return moves
+	}
+
+	plain := NewSolver(grid)
+	wandering, err := plain.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver(grid)
+	s.BacktrackPenalty = 3
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+
+	// Getting round the wall still means heading away for a while
+	if moves := away(s, path); moves == 0 || moves >= away(plain, wandering) {
+		t.Fatalf("path %v moves away %d times, plain path %v %d times",
+			PathPoints(path), moves, PathPoints(wandering), away(plain, wandering))
+	}
+	if path[len(path)-1].Index() != target {
+		t.Fatalf("path %v doesn't reach the target", PathPoints(path))
+	}
+}
+
+func TestFatigueFavoursFewerMoves(t *testing.T) {
+	grid := parseGrid(
+		"...#...",
+		"...#...",
+		"...#...",
+		"...#...",
+		".......",
+	)
+
+	// A toll in the wall: three moves across it cost more than the eight-move
+	// walk round the bottom, until every move made adds to the next one's cost
+	toll := grid[0][3]
+	toll.State = UNSEEN
+	toll.Weight = 100
+
+	s := NewSolver(grid)
+
+	path, err := s.FindPath(Point{2, 0}, Point{4, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsPoint(path, toll.Index()) {
+		t.Fatalf("plain cost goes through the toll: %v", PathPoints(path))
+	}
+
+	s.Fatigue = 0.5
+
+	path, err = s.FindPath(Point{2, 0}, Point{4, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 3 || !containsPoint(path, toll.Index()) {
+		t.Fatalf("tired path %v doesn't take the toll", PathPoints(path))
+	}
+}
+
+func TestExposedEntersFromCover(t *testing.T) {
+	grid := NewGrid(5, 5)
+	target := Point{2, 2}
+
+	// Fire from the west: the target can't be entered from that side
+	s := NewSolver(grid)
+	s.Exposed = func(cell *Cell, fromDir Direction) bool {
+		return cell.Index() == target && (fromDir == WEST || fromDir == NORTHWEST || fromDir == SOUTHWEST)
+	}
+
+	path, err := s.FindPath(Point{0, 2}, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	last, before := path[len(path)-1], path[len(path)-2]
+	if last.Index() != target {
+		t.Fatalf("path %v doesn't end at the target", PathPoints(path))
+	}
+	if before.X < target.X {
+		t.Fatalf("path %v enters the target from the exposed west side", PathPoints(path))
+	}
+}
+
+func TestMaxClimbCliffAndRamp(t *testing.T) {
+	// A cliff between columns 2 and 3 on the top two rows, a ramp along the
+	// bottom one
+	grid := NewGrid(7, 3)
+	for x := 0; x < 7; x++ {
+		if x >= 3 {
+			grid[0][x].Elevation = 5
+			grid[1][x].Elevation = 5
+		}
+		grid[2][x].Elevation = min(x, 5)
+	}
+
+	s := NewSolver(grid)
+	s.MaxClimb = 1
+
+	down, err := s.FindPath(Point{6, 0}, Point{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(down) != 7 {
+		t.Fatalf("path down %v doesn't drop straight off the cliff", PathPoints(down))
+	}
+
+	up, err := s.FindPath(Point{0, 0}, Point{6, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, up)
+	onRamp := false
+	for i := 1; i < len(up); i++ {
+		if climb := up[i].Elevation - up[i-1].Elevation; climb > 1 {
+			t.Fatalf("path up %v climbs %d from %v", PathPoints(up), climb, up[i-1].Index())
+		}
+		onRamp = onRamp || up[i].Y == 2
+	}
+	if !onRamp {
+		t.Fatalf("path up %v doesn't take the ramp", PathPoints(up))
+	}
+}
+
+func TestMaxDepthSpiral(t *testing.T) {
+	grid := parseGrid(
+		".......",
+		"######.",
+		".....#.",
+		".###.#.",
+		".#...#.",
+		".#####.",
+		".......",
+	)
+	s := NewSolver(grid, WithMovement(CARDINAL))
+
+	path, err := s.FindPath(Point{0, 0}, Point{2, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(path) != 31 || s.Stats.MaxDepth != len(path)-1 {
+		t.Fatalf("deepest chain %d, want the %d moves of the spiral", s.Stats.MaxDepth, len(path)-1)
+	}
+}
+
+func TestSortNeighboursStopsSooner(t *testing.T) {
+	openListOps := func(sorted bool) int {
+		ops := 0
+		s := NewSolver(NewGrid(8, 8), WithOpenSet(func() OpenSet { return countingOpenSet{NewHeapOpenSet(), &ops} }))
+		s.GoodEnoughH = 60
+		s.SortNeighbours = sorted
+
+		if _, err := s.FindPath(Point{0, 0}, Point{4, 7}); err != nil {
+			t.Fatal(err)
+		}
+
+		return ops
+	}
+
+	if unsorted, sorted := openListOps(false), openListOps(true); sorted >= unsorted {
+		t.Fatalf("sorted neighbours took %d open list operations, unsorted %d", sorted, unsorted)
+	}
+}
+
+func TestPriorityBiasFewerExpansions(t *testing.T) {
+	grid := parseGrid(
+		"...........",
+		"...........",
+		"......#....",
+		"......#....",
+		"......#....",
+		"......#....",
+		"......#....",
+		"...........",
+		"...........",
+	)
+	start, target := Point{2, 4}, Point{9, 4}
+
+	s := NewSolver(grid)
+	if _, err := s.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+	plain := s.Stats.Expansions
+
+	// Put off the cells facing the wall, so the search heads for its ends
+	s.PriorityBias = func(cell *Cell) int {
+		if cell.X >= 3 && cell.X <= 6 && cell.Y >= 2 && cell.Y <= 6 {
+			return 100
+		}
+
+		return 0
+	}
+
+	if _, err := s.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+	if s.Stats.Expansions >= plain {
+		t.Fatalf("biased search expanded %d cells, plain one %d", s.Stats.Expansions, plain)
+	}
+}
+
+func TestMinEnterGLongWayRound(t *testing.T) {
+	grid := parseGrid(
+		".....",
+		".#.##",
+		".#.##",
+		"...##",
+	)
+
+	// The gate on the top row only opens after 50 of travel, two steps along
+	// the top isn't enough but the loop round the bottom is
+	gate := grid[0][2]
+	gate.MinEnterG = 50
+
+	s := NewSolver(grid, WithMovement(CARDINAL))
+
+	path, err := s.FindPath(Point{0, 0}, Point{4, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	if containsPoint(path, Point{1, 0}) || !containsPoint(path, Point{2, 2}) {
+		t.Fatalf("path %v doesn't come round the bottom to the gate", PathPoints(path))
+	}
+	if s.Stats.Cost != 100 {
+		t.Fatalf("path costs %d, want 100", s.Stats.Cost)
+	}
+}
+
+func TestPreferDiagonalsMoreDiagonalMoves(t *testing.T) {
+	grid := parseGrid(
+		"#.###..#",
+		"......#.",
+		"#..#..#.",
+		"..#...#.",
+		"...#....",
+		"#.##.#..",
+		".......#",
+	)
+	start, target := Point{1, 3}, Point{4, 5}
+
+	diagonals := func(path []*Cell) int {
+		count := 0
+		for i := 1; i < len(path); i++ {
+			if isDiagonal(path[i-1], path[i]) {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	// A diagonal costs two straight moves, so there are paths of the same cost
+	// with more or fewer of them
+	plain := NewSolver(grid, WithCosts(10, 20))
+	path, err := plain.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, newSet := range map[string]func() OpenSet{"heap": NewHeapOpenSet, "list": NewListOpenSet, "bucket": NewBucketQueue} {
+		s := NewSolver(grid, WithCosts(10, 20), WithOpenSet(newSet))
+		s.PreferDiagonals = true
+
+		preferred, err := s.FindPath(start, target)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s.Stats.Cost != plain.Stats.Cost {
+			t.Fatalf("%s: cost %d, want %d", name, s.Stats.Cost, plain.Stats.Cost)
+		}
+
+		if diagonals(preferred) <= diagonals(path) {
+			t.Fatalf("%s: %d diagonal moves %v, without the preference %d %v", name,
+				diagonals(preferred), PathPoints(preferred), diagonals(path), PathPoints(path))
+		}
+	}
+}
+
+func TestCellCostMatchesWeight(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+
+	for i := 0; i < 50; i++ {
+		grid, start, target := randomGrid(rng, 4+rng.Intn(8), 4+rng.Intn(8))
+
+		// Costs kept outside the grid
+		multipliers := make([][]int, len(grid))
+		for y := range grid {
+			multipliers[y] = make([]int, len(grid[y]))
+			for x := range grid[y] {
+				multipliers[y][x] = 1 + rng.Intn(4)
+			}
+		}
+
+		external := NewSolver(grid, WithMovement(CARDINAL))
+		external.CellCost = func(x int, y int) int { return multipliers[y][x] }
+		path, err := external.FindPath(start, target)
+
+		// With straight moves only, a multiplier m costs as much as a Weight of
+		// 10*(m-1) on top of the move
+		weighted := grid.Clone()
+		for y := range weighted {
+			for x := range weighted[y] {
+				weighted[y][x].Weight = 10 * (multipliers[y][x] - 1)
+			}
+		}
+
+		s := NewSolver(weighted, WithMovement(CARDINAL))
+		want, wantErr := s.FindPath(start, target)
+
+		if (err == nil) != (wantErr == nil) || external.Stats.Cost != s.Stats.Cost ||
+			!reflect.DeepEqual(PathPoints(path), PathPoints(want)) {
+			t.Fatalf("grid %d: CellCost gave %v cost %d (%v), Weight %v cost %d (%v)", i,
+				PathPoints(path), external.Stats.Cost, err, PathPoints(want), s.Stats.Cost, wantErr)
+		}
+	}
+}
+
+func TestGreedyFirstPathFewerExpansions(t *testing.T) {
+	grid := NewGrid(40, 40)
+	scatterWalls(40, 40, func(x int, y int) { grid[y][x].State = DISABLED })
+	start, target := Point{0, 0}, Point{39, 39}
+
+	astar := NewSolver(grid)
+	if _, err := astar.FindPath(start, target); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver(grid)
+	s.Greedy, s.FirstPath = true, true
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	if path[0].Index() != start || path[len(path)-1].Index() != target {
+		t.Fatalf("greedy path runs %v to %v", path[0].Index(), path[len(path)-1].Index())
+	}
+
+	if s.Stats.Cost < astar.Stats.Cost {
+		t.Fatalf("greedy cost %d beats the shortest %d", s.Stats.Cost, astar.Stats.Cost)
+	}
+
+	if s.Stats.Expansions*2 > astar.Stats.Expansions {
+		t.Fatalf("greedy expanded %d cells, A* %d", s.Stats.Expansions, astar.Stats.Expansions)
+	}
+}
+
+func TestAdaptiveWeightWithinBudget(t *testing.T) {
+	grid := NewGrid(40, 40)
+	scatterWalls(40, 40, func(x int, y int) { grid[y][x].State = DISABLED })
+	start, target := Point{0, 0}, Point{39, 39}
+
+	plain := NewSolver(grid)
+	plain.MaxSteps = 150
+
+	if _, err := plain.FindPath(start, target); !errors.Is(err, ErrBudget) {
+		t.Fatalf("plain search gave %v, want it out of budget", err)
+	}
+
+	s := NewSolver(grid)
+	s.MaxSteps, s.AdaptiveWeight = 150, 3
+
+	path, err := s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	if path[len(path)-1].Index() != target || s.Stats.Expansions > s.MaxSteps {
+		t.Fatalf("path ends at %v after %d expansions", path[len(path)-1].Index(), s.Stats.Expansions)
+	}
+}
+
+func TestMaxReopensWarns(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	// Admissible but inconsistent, only every third diagonal has an estimate
+	inconsistent := func(from Point, to Point) int {
+		if (from.X+from.Y)%3 == 0 {
+			return Octile(from, to)
+		}
+
+		return 0
+	}
+
+	warned := 0
+
+	for i := 0; i < 100; i++ {
+		grid, start, target := randomGrid(rng, 5+rng.Intn(6), 5+rng.Intn(6))
+
+		s := NewSolver(grid, WithHeuristic(inconsistent))
+		s.MaxReopens = 1
+
+		path, err := s.FindPath(start, target)
+		if err != nil {
+			continue
+		}
+
+		checkContiguous(t, path)
+		if path[0].Index() != start || path[len(path)-1].Index() != target {
+			t.Fatalf("grid %d: path %v doesn't join %v and %v", i, PathPoints(path), start, target)
+		}
+
+		if hasWarning(s.Stats.Warnings, "hit MaxReopens 1") {
+			warned++
+		}
+	}
+
+	if warned == 0 {
+		t.Fatal("MaxReopens never kicked in")
+	}
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// node - what a search knows about one cell: G, H, open/closed state, parent,
+// moves from the start, diagonal moves in a row and in all, and heading of the
+// move that led here
+type node struct {
+	Cell   *Cell
+	G      int
+	H      int
+	State  CellState
+	Parent *node
+
+	Depth         int
+	Diagonals     int
+	DiagonalMoves int
+	Heading       Direction
+
+	// Reopens - times the cell went back on the open list after being expanded
+	Reopens int
+
+	// Estimated - H is only a lower bound so far, see LazyHeuristic
+	Estimated bool
+}
+
+func (n *node) F() int {
+	return n.G + n.H
+}
+
+// Stepper - a search that runs one expansion at a time, for animating or
+// spreading the work over several frames
+type Stepper struct {
+	solver *Solver
+
+	start      Point
+	target     Point
+	targetCell *Cell
+	open       OpenSet
+
+	// nodes - search state of every cell [row][column]
+	nodes [][]node
+
+	started time.Time
+
+	// reopensCapped - MaxReopens kept a cell closed
+	reopensCapped bool
+
+	// gated - a cell was passed over for its MinEnterG, which can keep the search
+	// from the cheapest path
+	gated bool
+
+	// neighbourBuf, costBuf - room for the neighbours of the cell being expanded
+	neighbourBuf [8]*Cell
+	costBuf      [8]int
+
+	done bool
+	path []*Cell
+	err  error
+}
+
+// NewStepper - sets up a search from start to target, nothing is expanded
+// until Step is called
+func (s *Solver) NewStepper(start Point, target Point) *Stepper {
+	st := &Stepper{solver: s}
+	st.reset(start, target)
+
+	return st
+}
+
+// reset - sets the stepper up for a new search, reusing the memory of the last
+// one where it can
+func (st *Stepper) reset(start Point, target Point) {
+	s := st.solver
+	grid := s.Grid
+
+	*st = Stepper{solver: s, open: st.open, nodes: st.nodes, started: time.Now()}
+	s.Stats = Stats{}
+
+	if open, ok := st.open.(interface{ reset() }); ok {
+		open.reset()
+	} else {
+		st.open = s.NewOpenSet()
+	}
+
+	if tb, ok := st.open.(TieBreaker); ok {
+		if s.PreferDiagonals {
+			// More diagonal moves first among equal priority
+			tb.SetTieBreak(func(cell *Cell) int { return -st.node(cell).DiagonalMoves })
+		} else {
+			tb.SetTieBreak(nil)
+		}
+	}
+
+	if s.useComponents && s.components == nil {
+		s.components = s.connectedComponents()
+	}
+
+	if len(st.nodes) != len(grid) {
+		st.nodes = make([][]node, len(grid))
+	}
+	for y := range grid {
+		if len(st.nodes[y]) != len(grid[y]) {
+			st.nodes[y] = make([]node, len(grid[y]))
+		}
+
+		for x := range grid[y] {
+			st.nodes[y][x] = node{Cell: grid[y][x]}
+		}
+	}
+
+	if s.SnapToWalkable {
+		if cell, ok := NearestWalkable(grid, start); ok {
+			start = cell.Index()
+		}
+		if cell, ok := NearestWalkable(grid, target); ok {
+			target = cell.Index()
+		}
+	}
+
+	st.start, st.target = start, target
+
+	if !grid.InBounds(start.X, start.Y) || !grid.InBounds(target.X, target.Y) {
+		st.finish(nil, ErrOutOfBounds)
+		return
+	}
+
+	startCell := grid[start.Y][start.X]
+	st.targetCell = grid[target.Y][target.X]
+
+	if !startCell.Walkable() || (s.goal == nil && !st.targetCell.Walkable()) {
+		st.finish(nil, &NoPathError{})
+		return
+	}
+
+	if s.components != nil && s.goal == nil && !s.components.Connected(start, target) {
+		st.finish(nil, &NoPathError{})
+		return
+	}
+
+	// Init the starting cell
+	startNode := st.node(startCell)
+	startNode.H = s.heuristic(start, target)
+	startNode.State = OPEN
+
+	// Add the start cell to the list of open cells
+	st.open.Push(startCell, st.priority(startNode))
+}
+
+// node - search state of cell
+func (st *Stepper) node(cell *Cell) *node {
+	return &st.nodes[cell.Row][cell.Col]
+}
+
+// priority - place of n on the open list, F plus PriorityBias
+func (st *Stepper) priority(n *node) int {
+	return st.solver.priority(n.G, n.H, n.Cell)
+}
+
+// priority - place on the open list of cell reached at cost g with estimate h,
+// g + h unless Greedy or a heuristic weight says otherwise, plus PriorityBias
+func (s *Solver) priority(g int, h int, cell *Cell) int {
+	priority := g + h
+	if s.Greedy {
+		priority = h
+	} else if s.MaxSteps > 0 && s.AdaptiveWeight > 1 {
+		used := float64(s.Stats.Expansions) / float64(s.MaxSteps)
+		priority = g + int(math.Round((1+(s.AdaptiveWeight-1)*used)*float64(h)))
+	} else if s.HeuristicWeight > 1 {
+		priority = g + int(math.Round(s.HeuristicWeight*float64(h)))
+	}
+
+	if s.PriorityBias != nil {
+		priority += s.PriorityBias(cell)
+	}
+
+	return priority
+}
+
+// moreDiagonal - whether PreferDiagonals favours reaching next from cur over the
+// route it has, at the same cost
+func (st *Stepper) moreDiagonal(cur *node, next *node) bool {
+	if !st.solver.PreferDiagonals {
+		return false
+	}
+
+	moves := cur.DiagonalMoves
+	if isDiagonal(cur.Cell, next.Cell) {
+		moves++
+	}
+
+	return moves > next.DiagonalMoves
+}
+
+// Step - expands the cheapest open cell, true once the search is over
+func (st *Stepper) Step() bool {
+	if st.done {
+		return true
+	}
+
+	if st.open.Len() == 0 {
+		st.finish(nil, &NoPathError{})
+		return true
+	}
+
+	if st.solver.MaxSteps > 0 && st.solver.Stats.Expansions >= st.solver.MaxSteps {
+		st.finish(nil, ErrBudget)
+		return true
+	}
+
+	// Remove the lowest cost element of the open list
+	cur := st.node(st.open.Pop())
+
+	if cur.Estimated {
+		cur.Estimated = false
+
+		if h := st.solver.heuristic(cur.Cell.Index(), st.target); h > cur.H {
+			// Worse than it looked, back in line with the real estimate
+			cur.H = h
+			st.open.Push(cur.Cell, st.priority(cur))
+			return false
+		}
+	}
+
+	cur.State = CLOSED
+	st.solver.Stats.Expansions++
+	st.solver.Stats.MaxDepth = max(st.solver.Stats.MaxDepth, cur.Depth)
+
+	if cur.Cell == st.targetCell || st.solver.closeEnough(cur.Cell, cur.H) {
+		st.solver.Stats.Cost = cur.G
+		st.solver.Stats.Optimal = !st.gated && !st.reopensCapped && st.solver.provablyOptimal()
+		st.finish(buildPath(cur), nil)
+		return true
+	}
+
+	st.ProcessNeighbours(cur.Cell)
+
+	if target := st.node(st.targetCell); st.solver.FirstPath && target.State == OPEN {
+		st.solver.Stats.Cost = target.G
+		st.finish(buildPath(target), nil)
+		return true
+	}
+
+	if st.open.Len() == 0 {
+		st.finish(nil, &NoPathError{})
+		return true
+	}
+
+	return false
+}
+
+func (st *Stepper) Done() bool {
+	return st.done
+}
+
+// Result - path found, or why there is none. Only meaningful once Done.
+func (st *Stepper) Result() ([]*Cell, error) {
+	return st.path, st.err
+}
+
+// backtrackCost - BacktrackPenalty when moving from n to cell raises the heuristic
+func (st *Stepper) backtrackCost(n *node, cell *Cell) int {
+	if st.solver.BacktrackPenalty == 0 {
+		return 0
+	}
+
+	if st.solver.heuristic(cell.Index(), st.target) > n.H {
+		return st.solver.BacktrackPenalty
+	}
+
+	return 0
+}
+
+// closeEnough - whether the search can stop at cell, estimated h from the
+// target, under GoodEnoughH or goal
+func (s *Solver) closeEnough(cell *Cell, h int) bool {
+	return (s.GoodEnoughH > 0 && h <= s.GoodEnoughH) || (s.goal != nil && s.goal(cell))
+}
+
+func (st *Stepper) finish(path []*Cell, err error) {
+	st.done = true
+	st.path = path
+	st.err = err
+
+	st.solver.Stats.Elapsed = time.Since(st.started)
+
+	if st.reopensCapped {
+		st.solver.Stats.Warnings = append(st.solver.Stats.Warnings, fmt.Sprintf(
+			"hit MaxReopens %d, the path may not be the shortest", st.solver.MaxReopens))
+	}
+
+	if noPath, ok := err.(*NoPathError); ok {
+		noPath.Expansions = st.solver.Stats.Expansions
+		noPath.Elapsed = st.solver.Stats.Elapsed
+	}
+}
+
+// OpenWall - turns the wall at x, y into open ground during the search. The
+// cell joins the open list if an expanded neighbour reaches it, so a search
+// that ran out of cells can carry on with Step instead of starting over.
+func (st *Stepper) OpenWall(x int, y int) {
+	grid := st.solver.Grid
+	if !grid.InBounds(x, y) || grid[y][x].State != DISABLED {
+		return
+	}
+
+	st.solver.SetWalkable(x, y, true)
+
+	// Expanded neighbours look around again, now that there's a way through
+	neighbours, _ := GetNeighbourCells(grid, grid[y][x])
+
+	for _, neighbour := range neighbours {
+		if st.node(neighbour).State == CLOSED {
+			st.ProcessNeighbours(neighbour)
+		}
+	}
+
+	if _, failed := st.err.(*NoPathError); failed && st.open.Len() > 0 {
+		st.done = false
+		st.err = nil
+	}
+}
+
+// CostGrid - G of every cell [row][column], for exporting a heatmap. Cells the
+// search never reached are -1, cells still on the open list hold the best G
+// found so far.
+func (st *Stepper) CostGrid() [][]int {
+	costs := make([][]int, len(st.nodes))
+
+	for y := range st.nodes {
+		costs[y] = make([]int, len(st.nodes[y]))
+
+		for x := range st.nodes[y] {
+			if st.nodes[y][x].State == UNSEEN {
+				costs[y][x] = -1
+			} else {
+				costs[y][x] = st.nodes[y][x].G
+			}
+		}
+	}
+
+	return costs
+}
+
+// CellData - search state of one cell, by grid index
+type CellData struct {
+	X     int
+	Y     int
+	F     int
+	G     int
+	H     int
+	State CellState
+}
+
+// ExploredData - F, G, H and state of every cell the search reached, row by row,
+// for plotting elsewhere. Cells still on the open list hold the best G found so
+// far.
+func (st *Stepper) ExploredData() []CellData {
+	var data []CellData
+
+	for y := range st.nodes {
+		for x := range st.nodes[y] {
+			n := &st.nodes[y][x]
+			if n.State == UNSEEN {
+				continue
+			}
+
+			data = append(data, CellData{X: x, Y: y, F: n.F(), G: n.G, H: n.H, State: n.State})
+		}
+	}
+
+	return data
+}
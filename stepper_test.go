@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Run with -race, the searches share one grid and never write to it
+func TestConcurrentSearchesShareGrid(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	grid, _, _ := randomGrid(rng, 24, 24)
+	before := grid.Clone()
+
+	queries := make([]Query, 64)
+	want := make([][]Point, len(queries))
+	for i := range queries {
+		queries[i] = Query{Point{rng.Intn(24), rng.Intn(24)}, Point{rng.Intn(24), rng.Intn(24)}}
+
+		path, _ := FindPath(grid, queries[i].Start, queries[i].Target)
+		want[i] = PathPoints(path)
+	}
+
+	var wg sync.WaitGroup
+	got := make([][]Point, len(queries))
+
+	for i := range queries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			st := NewSolver(grid).NewStepper(queries[i].Start, queries[i].Target)
+			for !st.Step() {
+			}
+
+			path, _ := st.Result()
+			got[i] = PathPoints(path)
+		}()
+	}
+
+	wg.Wait()
+
+	for i := range queries {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Fatalf("query %d: concurrent path %v, alone %v", i, got[i], want[i])
+		}
+	}
+
+	if !reflect.DeepEqual(grid, before) {
+		t.Fatal("searching changed the grid")
+	}
+}
+
+func TestOpenWallResumesFailedSearch(t *testing.T) {
+	grid := parseGrid(
+		"...#...",
+		"...#...",
+		"...#...",
+	)
+	s := NewSolver(grid)
+
+	st := s.NewStepper(Point{0, 1}, Point{6, 1})
+	for !st.Step() {
+	}
+
+	if _, err := st.Result(); !errors.Is(err, ErrNoPath) {
+		t.Fatalf("got %v before opening the wall, want ErrNoPath", err)
+	}
+
+	st.OpenWall(3, 2)
+
+	if st.Done() {
+		t.Fatal("search still over after opening a way through")
+	}
+
+	for !st.Step() {
+	}
+
+	path, err := st.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	if !containsPoint(path, Point{3, 2}) || path[len(path)-1].Index() != (Point{6, 1}) {
+		t.Fatalf("path %v doesn't go through the opening to the target", PathPoints(path))
+	}
+}
+
+func TestCostGrid(t *testing.T) {
+	s := NewSolver(NewGrid(10, 3))
+
+	st := s.NewStepper(Point{0, 1}, Point{3, 1})
+	for !st.Step() {
+	}
+
+	costs := st.CostGrid()
+	if len(costs) != 3 || len(costs[0]) != 10 {
+		t.Fatalf("got a %dx%d cost grid, want 10x3", len(costs[0]), len(costs))
+	}
+
+	if costs[1][0] != 0 {
+		t.Fatalf("start costs %d, want 0", costs[1][0])
+	}
+	if costs[1][3] != 30 {
+		t.Fatalf("target costs %d, want 30", costs[1][3])
+	}
+	for y := range costs {
+		if costs[y][9] != -1 {
+			t.Fatalf("far border cell (9, %d) costs %d, want -1 for unexplored", y, costs[y][9])
+		}
+	}
+}
+
+func TestExploredData(t *testing.T) {
+	s := NewSolver(parseGrid(
+		"......",
+		"..#...",
+		"......",
+	))
+
+	st := s.NewStepper(Point{0, 1}, Point{5, 1})
+	for !st.Step() {
+	}
+
+	path, err := st.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make(map[Point]CellData)
+	for _, d := range st.ExploredData() {
+		if d.F != d.G+d.H {
+			t.Fatalf("cell %d, %d has F %d, G %d and H %d", d.X, d.Y, d.F, d.G, d.H)
+		}
+		data[Point{d.X, d.Y}] = d
+	}
+
+	for _, cell := range path {
+		if _, ok := data[cell.Index()]; !ok {
+			t.Fatalf("no data for path cell %v", cell.Index())
+		}
+	}
+
+	if start := data[Point{0, 1}]; start.G != 0 || start.State != CLOSED {
+		t.Fatalf("start has G %d and state %v, want 0 and closed", start.G, start.State)
+	}
+
+	if target := data[Point{5, 1}]; target.G != s.Stats.Cost {
+		t.Fatalf("target has G %d, path cost %d", target.G, s.Stats.Cost)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+// FindPathFewestSteps - path from start to target through the fewest cells,
+// every move counting the same whatever it costs, by breadth first search
+func FindPathFewestSteps(grid Grid, start Point, target Point) ([]*Cell, error) {
+	if !grid.InBounds(start.X, start.Y) || !grid.InBounds(target.X, target.Y) {
+		return nil, ErrOutOfBounds
+	}
+
+	startCell, targetCell := grid[start.Y][start.X], grid[target.Y][target.X]
+	if !startCell.Walkable() || !targetCell.Walkable() {
+		return nil, &NoPathError{}
+	}
+
+	parent := map[*Cell]*Cell{startCell: nil}
+	queue := []*Cell{startCell}
+	expansions := 0
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		expansions++
+
+		if cell == targetCell {
+			var path []*Cell
+			for ; cell != nil; cell = parent[cell] {
+				path = append(path, cell)
+			}
+
+			return reversePath(path), nil
+		}
+
+		neighbours, _ := GetNeighbourCells(grid, cell)
+
+		for _, neighbour := range neighbours {
+			if _, seen := parent[neighbour]; !seen {
+				parent[neighbour] = cell
+				queue = append(queue, neighbour)
+			}
+		}
+	}
+
+	return nil, &NoPathError{Expansions: expansions}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindPathFewestStepsIgnoresWeight(t *testing.T) {
+	grid := parseGrid(
+		".......",
+		".#####.",
+		".......",
+	)
+	for x := 1; x <= 5; x++ {
+		grid[2][x].Weight = 50
+	}
+	start, target := Point{0, 2}, Point{6, 2}
+
+	steps, err := FindPathFewestSteps(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, steps)
+
+	// Straight through the heavy cells
+	if len(steps) != 7 || !containsPoint(steps, Point{3, 2}) {
+		t.Fatalf("fewest steps path %v, want the 7 cells of the bottom row", PathPoints(steps))
+	}
+
+	cheapest, err := NewSolver(grid).FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Round the top, more cells but cheaper
+	if len(cheapest) <= len(steps) || !containsPoint(cheapest, Point{3, 0}) {
+		t.Fatalf("cheapest path %v, want the longer way over the top", PathPoints(cheapest))
+	}
+}
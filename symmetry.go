@@ -0,0 +1,24 @@
+package main
+
+// HasSymmetry - whether the walls look the same mirrored left to right
+// (horizontal) and top to bottom (vertical). On such grids many paths tie on
+// cost and the search spends time expanding mirror images of the same route.
+func (g Grid) HasSymmetry() (horizontal bool, vertical bool) {
+	horizontal, vertical = true, true
+
+	for y := range g {
+		for x := range g[y] {
+			wall := g[y][x].State == DISABLED
+
+			if mirror := len(g[y]) - 1 - x; horizontal && wall != (g[y][mirror].State == DISABLED) {
+				horizontal = false
+			}
+
+			if mirror := len(g) - 1 - y; vertical && (x >= len(g[mirror]) || wall != (g[mirror][x].State == DISABLED)) {
+				vertical = false
+			}
+		}
+	}
+
+	return horizontal, vertical
+}
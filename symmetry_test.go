@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestHasSymmetry(t *testing.T) {
+	horizontal, vertical := NewGrid(6, 4).HasSymmetry()
+	if !horizontal || !vertical {
+		t.Fatalf("open grid: horizontal %v, vertical %v, want both", horizontal, vertical)
+	}
+
+	mirrored := parseGrid(
+		"#....#",
+		"..##..",
+		"......",
+	)
+	if horizontal, vertical := mirrored.HasSymmetry(); !horizontal || vertical {
+		t.Fatalf("left-right mirrored grid: horizontal %v, vertical %v", horizontal, vertical)
+	}
+
+	if horizontal, vertical := maze().HasSymmetry(); horizontal || vertical {
+		t.Fatalf("maze: horizontal %v, vertical %v, want neither", horizontal, vertical)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+// NoTag - Tag of cells that have none, which PreferTag can't prefer
+const NoTag = 0
+
+// PreferTag - make entering cells tagged tag cost discount less, so paths keep
+// to them where it doesn't take them too far out of the way. tag can't be NoTag,
+// that discount would go to every untagged cell; Validate warns about it and the
+// discount isn't applied.
+func PreferTag(tag int, discount int) Option {
+	return func(s *Solver) {
+		s.PreferredTag = tag
+		s.TagDiscount = discount
+	}
+}
+
+// discounted - cost of a move into cell after TagDiscount, at least 0
+func (s *Solver) discounted(cell *Cell, cost int) int {
+	if s.TagDiscount == 0 || s.PreferredTag == NoTag || cell.Tag != s.PreferredTag {
+		return cost
+	}
+
+	return max(cost-s.TagDiscount, 0)
+}
+
+// tagDiscount - TagDiscount as applied, 0 without a preferred tag
+func (s *Solver) tagDiscount() int {
+	if s.PreferredTag == NoTag {
+		return 0
+	}
+
+	return s.TagDiscount
+}
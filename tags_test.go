@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestPreferTagKeepsToRoad(t *testing.T) {
+	const road = 1
+
+	// A road bending round the top, eight more to walk than the straight way
+	grid := NewGrid(5, 3)
+	for x := 1; x < 4; x++ {
+		grid[0][x].Tag = road
+	}
+
+	start, target := Point{0, 1}, Point{4, 1}
+
+	path, err := FindPath(grid, start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsPoint(path, Point{2, 0}) {
+		t.Fatalf("path %v takes the road without being told to", PathPoints(path))
+	}
+
+	// Three road cells at 4 off each make up for the 8
+	s := NewSolver(grid, PreferTag(road, 4))
+
+	path, err = s.FindPath(start, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsPoint(path, Point{2, 0}) {
+		t.Fatalf("path %v stays off the road", PathPoints(path))
+	}
+}
+
+func TestPreferTagRejectsNoTag(t *testing.T) {
+	s := NewSolver(NewGrid(5, 3), PreferTag(NoTag, 4))
+
+	if !hasWarning(s.Warnings, "NoTag") {
+		t.Fatalf("no warning about preferring NoTag in %v", s.Warnings)
+	}
+
+	if _, err := s.FindPath(Point{0, 1}, Point{4, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Stats.Cost != 40 {
+		t.Fatalf("untagged cells were discounted, cost %d, want 40", s.Stats.Cost)
+	}
+}
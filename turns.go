@@ -0,0 +1,250 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+)
+
+// headingState - a cell and the heading of the move that reached it, NONE at
+// the start
+type headingState struct {
+	cell    *Cell
+	heading Direction
+}
+
+// headingSearch - A* over headingStates, so that routes reaching a cell with
+// different headings are kept apart and each turn is charged exactly
+type headingSearch struct {
+	s      *Solver
+	start  Point
+	target Point
+}
+
+// findPathHeadings - FindPath for TurnWeight, searching over headingStates
+func (s *Solver) findPathHeadings(start Point, target Point) ([]*Cell, error) {
+	started := time.Now()
+	e := &headingSearch{s: s, start: start, target: target}
+
+	path, err := e.findPath()
+
+	s.Stats.Elapsed = time.Since(started)
+	if noPath, ok := err.(*NoPathError); ok {
+		noPath.Expansions = s.Stats.Expansions
+		noPath.Elapsed = s.Stats.Elapsed
+	}
+
+	return path, err
+}
+
+func (e *headingSearch) findPath() ([]*Cell, error) {
+	s := e.s
+	grid := s.Grid
+
+	if s.useComponents && s.components == nil {
+		s.components = s.connectedComponents()
+	}
+
+	if s.SnapToWalkable {
+		if cell, ok := NearestWalkable(grid, e.start); ok {
+			e.start = cell.Index()
+		}
+		if cell, ok := NearestWalkable(grid, e.target); ok {
+			e.target = cell.Index()
+		}
+	}
+
+	if !grid.InBounds(e.start.X, e.start.Y) || !grid.InBounds(e.target.X, e.target.Y) {
+		return nil, ErrOutOfBounds
+	}
+
+	startCell, targetCell := grid[e.start.Y][e.start.X], grid[e.target.Y][e.target.X]
+	if !startCell.Walkable() || (s.goal == nil && !targetCell.Walkable()) {
+		return nil, &NoPathError{}
+	}
+
+	if s.components != nil && s.goal == nil && !s.components.Connected(e.start, e.target) {
+		return nil, &NoPathError{}
+	}
+
+	first := headingState{startCell, NONE}
+	firstH := s.heuristic(e.start, e.target)
+	g := map[headingState]int{first: 0}
+	depth := map[headingState]int{first: 0}
+	parent := make(map[headingState]headingState)
+	closed := make(map[headingState]bool)
+	reopens := make(map[headingState]int)
+	queue := &headingQueue{{first, 0, firstH, s.priority(0, firstH, startCell)}}
+	reopensCapped, gated := false, false
+
+	pathTo := func(last headingState) []*Cell {
+		path := []*Cell{last.cell}
+		for st := last; st != first; {
+			st = parent[st]
+			path = append(path, st.cell)
+		}
+
+		return reversePath(path)
+	}
+
+	defer func() {
+		if reopensCapped {
+			s.Stats.Warnings = append(s.Stats.Warnings, fmt.Sprintf(
+				"hit MaxReopens %d, the path may not be the shortest", s.MaxReopens))
+		}
+	}()
+
+	var neighbourBuf [8]*Cell
+	var costBuf [8]int
+
+	for queue.Len() > 0 {
+		if s.MaxSteps > 0 && s.Stats.Expansions >= s.MaxSteps {
+			return nil, ErrBudget
+		}
+
+		item := heap.Pop(queue).(headingItem)
+		cur := item.state
+
+		if closed[cur] || item.g != g[cur] {
+			// Stale entry, the state was reached cheaper since
+			continue
+		}
+
+		closed[cur] = true
+		s.Stats.Expansions++
+		s.Stats.MaxDepth = max(s.Stats.MaxDepth, depth[cur])
+
+		if cur.cell == targetCell || s.closeEnough(cur.cell, item.h) {
+			s.Stats.Cost = item.g
+			s.Stats.Optimal = !gated && !reopensCapped && s.provablyOptimal()
+
+			return pathTo(cur), nil
+		}
+
+		neighbours, costs := s.neighbours(cur.cell, neighbourBuf[:0], costBuf[:0])
+		reached, reachedTarget := headingState{}, false
+
+		for n, neighbour := range neighbours {
+			cost, ok := e.moveCost(cur, neighbour, costs[n])
+			if !ok {
+				continue
+			}
+
+			next := headingState{neighbour, directionOf(cur.cell, neighbour)}
+			newG := item.g + cost
+
+			if newG < neighbour.MinEnterG {
+				// Gate is still shut at this cost
+				gated = true
+				continue
+			}
+
+			if old, seen := g[next]; seen && old <= newG {
+				continue
+			}
+
+			if closed[next] {
+				// Only happens with an inconsistent heuristic
+				if s.MaxReopens > 0 && reopens[next] >= s.MaxReopens {
+					reopensCapped = true
+					continue
+				}
+
+				delete(closed, next)
+				reopens[next]++
+			}
+
+			g[next] = newG
+			depth[next] = depth[cur] + 1
+			parent[next] = cur
+
+			h := s.heuristic(neighbour.Index(), e.target)
+			heap.Push(queue, headingItem{next, newG, h, s.priority(newG, h, neighbour)})
+
+			if neighbour == targetCell && (!reachedTarget || newG < g[reached]) {
+				reached, reachedTarget = next, true
+			}
+		}
+
+		if s.FirstPath && reachedTarget {
+			s.Stats.Cost = g[reached]
+			return pathTo(reached), nil
+		}
+	}
+
+	return nil, &NoPathError{}
+}
+
+// moveCost - cost of the move from cur into a neighbour costing base, counted
+// as ProcessNeighbours counts it with TurnCostFunc and TurnWeight going by the
+// heading of cur. False when the move isn't allowed.
+func (e *headingSearch) moveCost(cur headingState, to *Cell, base int) (int, bool) {
+	s := e.s
+	from := cur.cell
+
+	if s.NeighbourFilter != nil && !s.NeighbourFilter(from, to) {
+		return 0, false
+	}
+
+	if s.RequireLOSFromStart && !LineOfSight(s.Grid, e.start, to.Index()) {
+		return 0, false
+	}
+
+	if !s.fits(to) || !s.canClimb(from, to) {
+		return 0, false
+	}
+
+	extra, ok := s.congestionCost(to)
+	if !ok {
+		return 0, false
+	}
+
+	exposed, ok := s.exposedCost(from, to)
+	if !ok {
+		return 0, false
+	}
+
+	cost := s.cellCost(to, s.stepCost(from, to, base)) + extra + exposed +
+		s.cornerCost(from, to) + s.directionCost(from, to) + s.surcharge[to.Index()] + to.Weight
+
+	if cur.heading != NONE {
+		heading := directionOf(from, to)
+
+		if s.TurnCostFunc != nil {
+			cost += s.TurnCostFunc(cur.heading, heading)
+		}
+
+		if heading != cur.heading {
+			cost += s.TurnWeight
+		}
+	}
+
+	if s.BacktrackPenalty != 0 && s.heuristic(to.Index(), e.target) > s.heuristic(from.Index(), e.target) {
+		cost += s.BacktrackPenalty
+	}
+
+	return s.discounted(to, cost), true
+}
+
+type headingItem struct {
+	state    headingState
+	g        int
+	h        int
+	priority int
+}
+
+// headingQueue - min-heap of heading states by priority, for container/heap
+type headingQueue []headingItem
+
+func (q headingQueue) Len() int            { return len(q) }
+func (q headingQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q headingQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *headingQueue) Push(x interface{}) { *q = append(*q, x.(headingItem)) }
+
+func (q *headingQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
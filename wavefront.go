@@ -0,0 +1,46 @@
+package main
+
+import (
+	"sort"
+)
+
+// Wavefront - rings of cells spreading out from start, ring i holding the cells
+// first reached after i moves (diagonal moves counting as one), row by row
+// within a ring. Ring 0 is start alone; nil when start is off the grid or a wall.
+func Wavefront(grid Grid, start Point) [][]Point {
+	if !grid.InBounds(start.X, start.Y) || grid[start.Y][start.X].State == DISABLED {
+		return nil
+	}
+
+	seen := map[*Cell]bool{grid[start.Y][start.X]: true}
+	frontier := []*Cell{grid[start.Y][start.X]}
+
+	var rings [][]Point
+
+	for len(frontier) > 0 {
+		var next []*Cell
+
+		ring := make([]Point, 0, len(frontier))
+		for _, cell := range frontier {
+			ring = append(ring, cell.Index())
+
+			neighbours, _ := GetNeighbourCells(grid, cell)
+
+			for _, neighbour := range neighbours {
+				if !seen[neighbour] {
+					seen[neighbour] = true
+					next = append(next, neighbour)
+				}
+			}
+		}
+
+		sort.Slice(ring, func(i, j int) bool {
+			return ring[i].Y < ring[j].Y || (ring[i].Y == ring[j].Y && ring[i].X < ring[j].X)
+		})
+
+		rings = append(rings, ring)
+		frontier = next
+	}
+
+	return rings
+}
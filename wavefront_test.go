@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestWavefrontChebyshevRings(t *testing.T) {
+	grid := NewGrid(7, 5)
+	start := Point{2, 1}
+
+	rings := Wavefront(grid, start)
+
+	total := 0
+	for i, ring := range rings {
+		for _, p := range ring {
+			if d := max(abs(p.X-start.X), abs(p.Y-start.Y)); d != i {
+				t.Fatalf("%v is %d moves from the start but in ring %d", p, d, i)
+			}
+		}
+		total += len(ring)
+	}
+
+	if total != 7*5 {
+		t.Fatalf("rings hold %d cells, want all %d", total, 7*5)
+	}
+	if len(rings) != 5 || len(rings[1]) != 8 {
+		t.Fatalf("got %d rings with %d cells in the first, want 5 rings and 8", len(rings), len(rings[1]))
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+// FindPathWithinRange - cheapest path from start to any cell at most reach moves
+// from target, counting diagonal moves as one, e.g. to get within shooting
+// range. Stops at the first such cell, which is start itself if it's in range.
+// The target itself may be a wall, such as an enemy tower.
+func FindPathWithinRange(grid Grid, start Point, target Point, reach int) ([]*Cell, error) {
+	reach = max(reach, 0)
+
+	s := NewSolver(grid)
+
+	// Lower bound on the cost to the nearest cell in range, so the first one
+	// expanded is the cheapest to reach
+	s.Heuristic = func(from Point, to Point) int {
+		return max(Octile(from, to)-reach*s.DiagonalCost, 0)
+	}
+
+	s.goal = func(cell *Cell) bool {
+		return max(abs(cell.Col-target.X), abs(cell.Row-target.Y)) <= reach
+	}
+
+	return s.FindPath(start, target)
+}
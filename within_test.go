@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindPathWithinRange(t *testing.T) {
+	grid := NewGrid(10, 3)
+	target := Point{9, 1}
+
+	path, err := FindPathWithinRange(grid, Point{0, 1}, target, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkContiguous(t, path)
+	if last := path[len(path)-1].Index(); last != (Point{7, 1}) {
+		t.Fatalf("path %v stops at %v, want two tiles short of the target at (7, 1)", PathPoints(path), last)
+	}
+}
+
+func TestFindPathWithinRangeOfWall(t *testing.T) {
+	grid := parseGrid(
+		"..........",
+		".........#",
+		"..........",
+	)
+
+	path, err := FindPathWithinRange(grid, Point{0, 1}, Point{9, 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last := path[len(path)-1].Index(); last.X != 7 {
+		t.Fatalf("path %v stops at %v, want two tiles short of the wall", PathPoints(path), last)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+// Workspace - memory for running many searches one after the other, such as
+// every frame of a game. The open list and per-cell bookkeeping are kept between
+// searches instead of being allocated anew, so repeated searches on the same
+// grid allocate little more than the paths they return. Not safe for
+// concurrent use, give each goroutine a workspace of its own.
+type Workspace struct {
+	stepper *Stepper
+}
+
+// NewWorkspace - workspace for searches with the settings of s
+func (s *Solver) NewWorkspace() *Workspace {
+	return &Workspace{stepper: &Stepper{solver: s}}
+}
+
+// Solve - same as FindPath on the workspace's solver
+func (w *Workspace) Solve(start Point, target Point) ([]*Cell, error) {
+	return w.stepper.solver.solve(w.stepper, start, target)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWorkspaceMatchesFindPath(t *testing.T) {
+	grid := NewGrid(30, 20)
+	scatterWalls(30, 20, func(x int, y int) { grid[y][x].State = DISABLED })
+
+	s := NewSolver(grid)
+	w := s.NewWorkspace()
+
+	queries := []Query{
+		{Point{0, 0}, Point{29, 19}},
+		{Point{29, 19}, Point{0, 0}},
+		{Point{0, 0}, Point{0, 0}},
+		{Point{0, 19}, Point{29, 0}},
+	}
+
+	// The same workspace again and again, in both directions
+	for _, q := range queries {
+		want, wantErr := FindPath(grid, q.Start, q.Target)
+		path, err := w.Solve(q.Start, q.Target)
+
+		if (err == nil) != (wantErr == nil) || !reflect.DeepEqual(PathPoints(path), PathPoints(want)) {
+			t.Fatalf("%v to %v: workspace gave %v (%v), FindPath %v (%v)",
+				q.Start, q.Target, PathPoints(path), err, PathPoints(want), wantErr)
+		}
+	}
+}
+
+func BenchmarkWorkspaceSolve(b *testing.B) {
+	grid := NewGrid(benchmarkMapSize, benchmarkMapSize)
+	scatterWalls(benchmarkMapSize, benchmarkMapSize, func(x int, y int) { grid[y][x].State = DISABLED })
+
+	w := NewSolver(grid).NewWorkspace()
+	target := Point{benchmarkMapSize - 1, benchmarkMapSize - 1}
+
+	// The first search sizes the workspace
+	if _, err := w.Solve(Point{0, 0}, target); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := w.Solve(Point{0, 0}, target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}